@@ -0,0 +1,123 @@
+package dmap
+
+import (
+	"fmt"
+)
+
+var (
+	errorEmptySlice = "slice at %v is empty"
+)
+
+// Sum returns the sum of the numbers in the []interface{} at a given path. Every element has to be a number, and the sum of an empty slice is 0.
+func (d *DMap) Sum(path ...interface{}) (float64, error) {
+	numbers, err := d.float64s(path)
+	if err != nil {
+		return 0, err
+	}
+
+	sum := 0.0
+	for _, n := range numbers {
+		sum += n
+	}
+
+	return sum, nil
+}
+
+// Min returns the smallest number in the []interface{} at a given path. Every element has to be a number, and an empty slice returns an error.
+func (d *DMap) Min(path ...interface{}) (float64, error) {
+	numbers, err := d.nonEmptyFloat64s(path)
+	if err != nil {
+		return 0, err
+	}
+
+	min := numbers[0]
+	for _, n := range numbers[1:] {
+		if n < min {
+			min = n
+		}
+	}
+
+	return min, nil
+}
+
+// Max returns the largest number in the []interface{} at a given path. Every element has to be a number, and an empty slice returns an error.
+func (d *DMap) Max(path ...interface{}) (float64, error) {
+	numbers, err := d.nonEmptyFloat64s(path)
+	if err != nil {
+		return 0, err
+	}
+
+	max := numbers[0]
+	for _, n := range numbers[1:] {
+		if n > max {
+			max = n
+		}
+	}
+
+	return max, nil
+}
+
+// Avg returns the mean of the numbers in the []interface{} at a given path. Every element has to be a number, and an empty slice returns an error.
+func (d *DMap) Avg(path ...interface{}) (float64, error) {
+	numbers, err := d.nonEmptyFloat64s(path)
+	if err != nil {
+		return 0, err
+	}
+
+	sum := 0.0
+	for _, n := range numbers {
+		sum += n
+	}
+
+	return sum / float64(len(numbers)), nil
+}
+
+// float64s returns the elements of the []interface{} at a given path as float64, and an error for the first element which is not a number.
+func (d *DMap) float64s(path []interface{}) ([]float64, error) {
+	data, err := d.GetSliceI(path...)
+	if err != nil {
+		return nil, err
+	}
+
+	numbers := make([]float64, len(data))
+	for i, elem := range data {
+		numbers[i], err = floatFromNumber(elem, 64, appendPath(path, i))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return numbers, nil
+}
+
+// nonEmptyFloat64s is like float64s, but also returns an error if the slice is empty.
+func (d *DMap) nonEmptyFloat64s(path []interface{}) ([]float64, error) {
+	numbers, err := d.float64s(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(numbers) == 0 {
+		return nil, fmt.Errorf(errorEmptySlice, path)
+	}
+
+	return numbers, nil
+}
+
+// Reduce calls fn with an accumulator and every element of the []interface{} at a given path in order, starting with init as the accumulator and then using the value returned by fn, and returns the last accumulator. If fn returns an error, Reduce stops and returns it.
+func (d *DMap) Reduce(init interface{}, fn func(acc interface{}, elem *DMap) (interface{}, error), path ...interface{}) (interface{}, error) {
+	data, err := d.GetSliceI(path...)
+	if err != nil {
+		return nil, err
+	}
+
+	acc := init
+	for _, elem := range data {
+		acc, err = fn(acc, d.wrap(elem))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return acc, nil
+}
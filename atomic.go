@@ -0,0 +1,22 @@
+package dmap
+
+import (
+	"sync/atomic"
+)
+
+// AtomicDMap holds a dmap which can be swapped by one goroutine while others are loading it, like a configuration reloaded in the background. Its zero value holds a nil dmap and is ready to use.
+// Readers always get a whole dmap, either the old one or the new one. The dmaps are not copied though, so a dmap should not be modified once it has been stored: store a new one instead, or store a frozen view of it.
+type AtomicDMap struct {
+	v atomic.Value
+}
+
+// Store makes d the dmap returned by Load.
+func (a *AtomicDMap) Store(d *DMap) {
+	a.v.Store(d)
+}
+
+// Load returns the dmap stored last, or nil if none was stored.
+func (a *AtomicDMap) Load() *DMap {
+	d, _ := a.v.Load().(*DMap)
+	return d
+}
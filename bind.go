@@ -0,0 +1,225 @@
+package dmap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	errorBindTarget = "bind target of type %T is not a non-nil pointer to a struct"
+	errorIntoTarget = "target of type %T is not a non-nil pointer"
+	errorAsTarget   = "target type is nil"
+	errorBindField  = "struct %v has no settable field %v"
+	errorBindValue  = "cannot assign data of type %T at %v to a value of type %v: %w"
+)
+
+// Bind sets the fields of the struct pointed to by out from the data at the paths given by mapping, which maps a field name to a path. The fields are set in sorted order of their names, and the first path which does not exist or field which cannot be set returns an error.
+// A value is assigned as it is when its type allows it, numbers are converted to the numeric type of the field like the numeric getters do, and a nil value sets the zero value. Any other value is marshalled to JSON and unmarshalled into the field, which covers nested structs, slices and maps as well as the types implementing json.Unmarshaler.
+func (d *DMap) Bind(out interface{}, mapping map[string][]interface{}) error {
+	rv := reflect.ValueOf(out)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf(errorBindTarget, out)
+	}
+	rv = rv.Elem()
+
+	names := make([]string, 0, len(mapping))
+	for name := range mapping {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		field := rv.FieldByName(name)
+		if !field.IsValid() || !field.CanSet() {
+			return fmt.Errorf(errorBindField, rv.Type(), name)
+		}
+
+		path := mapping[name]
+		data, err := d.Get(path...)
+		if err != nil {
+			return err
+		}
+
+		err = assign(field, data.Data(), path, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetInto stores the data at a given path in the value pointed to by out, converting it like Bind does, so a map can be decoded into a struct. The keys of the map which match no field of the struct are ignored, unless the dmap was parsed by a Parser with DisallowUnknownFields.
+func (d *DMap) GetInto(out interface{}, path ...interface{}) error {
+	return d.getInto(out, path, d.options != nil && d.options.disallowUnknownFields)
+}
+
+// GetIntoStrict is like GetInto, but returns an error for a map key which matches no field of the struct, like json.Decoder.DisallowUnknownFields.
+func (d *DMap) GetIntoStrict(out interface{}, path ...interface{}) error {
+	return d.getInto(out, path, true)
+}
+
+func (d *DMap) getInto(out interface{}, path []interface{}, strict bool) error {
+	rv := reflect.ValueOf(out)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf(errorIntoTarget, out)
+	}
+
+	data, err := d.Get(path...)
+	if err != nil {
+		return err
+	}
+
+	return assign(rv.Elem(), data.Data(), path, strict)
+}
+
+// GetAs returns the data at a given path converted to the target type like in Bind, also parsing strings for durations, numbers and bools.
+func (d *DMap) GetAs(target reflect.Type, path ...interface{}) (interface{}, error) {
+	if target == nil {
+		return nil, fmt.Errorf(errorAsTarget)
+	}
+
+	data, err := d.Get(path...)
+	if err != nil {
+		return nil, err
+	}
+
+	v := data.Data()
+	dst := reflect.New(target).Elem()
+	_, isString := v.(string)
+
+	switch {
+	case target == reflect.TypeOf(time.Duration(0)) && isString:
+		duration, err := time.ParseDuration(v.(string))
+		if err != nil {
+			return nil, fmt.Errorf(errorBindValue, v, path, target, err)
+		}
+		return duration, nil
+
+	case target.Kind() == reflect.Bool && (isString || isNumber(v)):
+		b, err := d.GetBoolLoose(path...)
+		if err != nil {
+			return nil, err
+		}
+		dst.SetBool(b)
+		return dst.Interface(), nil
+
+	case isString && isNumericKind(target.Kind()):
+		n, err := d.getNumberString(path, false)
+		if err != nil {
+			return nil, err
+		}
+		v = n
+	}
+
+	err = assign(dst, v, path, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return dst.Interface(), nil
+}
+
+// isNumericKind checks if k is the kind of an integer or float type.
+func isNumericKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// assign stores v in the settable value dst, converting it as described in Bind. If strict is true, the keys of a map which match no field of a struct return an error. The path of v is used for errors.
+func assign(dst reflect.Value, v interface{}, path []interface{}, strict bool) error {
+	t := dst.Type()
+
+	if v == nil {
+		dst.Set(reflect.Zero(t))
+		return nil
+	}
+
+	if reflect.TypeOf(v).AssignableTo(t) {
+		dst.Set(reflect.ValueOf(v))
+		return nil
+	}
+
+	if isNumber(v) {
+		switch t.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			i, err := intFromNumber(v, t.Bits(), path)
+			if err != nil {
+				return err
+			}
+			dst.SetInt(i)
+			return nil
+
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			u, err := uintFromNumber(v, t.Bits(), path)
+			if err != nil {
+				return err
+			}
+			dst.SetUint(u)
+			return nil
+
+		case reflect.Float32, reflect.Float64:
+			f, err := floatFromNumber(v, t.Bits(), path)
+			if err != nil {
+				return err
+			}
+			dst.SetFloat(f)
+			return nil
+		}
+	}
+
+	// Values of a named type with the same kind, like a string for a type Color string, are converted directly.
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == t.Kind() && rv.Kind() != reflect.Struct && rv.Type().ConvertibleTo(t) {
+		dst.Set(rv.Convert(t))
+		return nil
+	}
+
+	converted, err := stringKeys(v, path, true)
+	if err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(converted)
+	if err != nil {
+		return fmt.Errorf(errorBindValue, v, path, t, err)
+	}
+
+	ptr := reflect.New(t)
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	if strict {
+		decoder.DisallowUnknownFields()
+	}
+	err = decoder.Decode(ptr.Interface())
+	if err != nil {
+		if field, ok := unknownField(err); ok {
+			path = appendPath(path, field)
+		}
+		return fmt.Errorf(errorBindValue, v, path, t, err)
+	}
+
+	dst.Set(ptr.Elem())
+	return nil
+}
+
+// unknownField returns the name of the field in the error returned by a json.Decoder with DisallowUnknownFields for an unknown field.
+func unknownField(err error) (string, bool) {
+	quoted, ok := strings.CutPrefix(err.Error(), "json: unknown field ")
+	if !ok {
+		return "", false
+	}
+
+	field, err := strconv.Unquote(quoted)
+	return field, err == nil
+}
@@ -0,0 +1,146 @@
+package dmap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"unicode/utf16"
+)
+
+// ToCanonicalJSON returns the data of the dmap serialized as in RFC 8785, the JSON Canonicalization Scheme, for hashing or signing it: without whitespace, with the keys of every object sorted by their UTF-16 code units, with numbers written like ECMAScript does, and with strings escaped minimally.
+// The data is first marshalled like JSONCodec does, so the keys of interface-keyed maps are converted with fmt.Sprint and invalid UTF-8 in strings is replaced by U+FFFD. Every number is then used as an IEEE 754 double, as the RFC requires, which changes the integers beyond 2^53 that cannot be represented exactly.
+func (d *DMap) ToCanonicalJSON() ([]byte, error) {
+	b, err := JSONCodec.Encode(d.Data())
+	if err != nil {
+		return nil, err
+	}
+
+	var v interface{}
+	err = json.Unmarshal(b, &v)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	err = writeCanonical(&buf, v, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// writeCanonical writes v, as unmarshalled by encoding/json, to b in canonical form. The path of v is used for errors.
+func writeCanonical(b *bytes.Buffer, v interface{}, path []interface{}) error {
+	switch v := v.(type) {
+	case nil:
+		b.WriteString("null")
+
+	case bool:
+		b.WriteString(strconv.FormatBool(v))
+
+	case float64:
+		if v == 0 {
+			// Negative zero is written as 0 as well.
+			b.WriteByte('0')
+			break
+		}
+
+		// encoding/json writes floats like the ECMAScript Number.prototype.toString required by the RFC.
+		n, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		b.Write(n)
+
+	case string:
+		writeCanonicalString(b, v)
+
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			return lessUTF16(keys[i], keys[j])
+		})
+
+		b.WriteByte('{')
+		for i, k := range keys {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+
+			writeCanonicalString(b, k)
+			b.WriteByte(':')
+
+			err := writeCanonical(b, v[k], appendPath(path, k))
+			if err != nil {
+				return err
+			}
+		}
+		b.WriteByte('}')
+
+	case []interface{}:
+		b.WriteByte('[')
+		for i, elem := range v {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+
+			err := writeCanonical(b, elem, appendPath(path, i))
+			if err != nil {
+				return err
+			}
+		}
+		b.WriteByte(']')
+
+	default:
+		return fmt.Errorf(errorUnexpectedType, path)
+	}
+
+	return nil
+}
+
+// writeCanonicalString writes s to b as a JSON string in which only the quotation mark, the reverse solidus and the control characters are escaped.
+func writeCanonicalString(b *bytes.Buffer, s string) {
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(b, `\u%04x`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+}
+
+// lessUTF16 compares a and b by their UTF-16 code units.
+func lessUTF16(a, b string) bool {
+	x, y := utf16.Encode([]rune(a)), utf16.Encode([]rune(b))
+	for i := 0; i < len(x) && i < len(y); i++ {
+		if x[i] != y[i] {
+			return x[i] < y[i]
+		}
+	}
+	return len(x) < len(y)
+}
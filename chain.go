@@ -0,0 +1,67 @@
+package dmap
+
+// Chain builds a path step by step and gets the data at it with a typed method at the end, like d.Chain().Key("a").Index(0).String(). The path is only resolved by the typed method, which returns the error of the first step that failed.
+type Chain struct {
+	d    *DMap
+	path []interface{}
+}
+
+// Chain returns a new chain which starts at the root of the dmap.
+func (d *DMap) Chain() *Chain {
+	return &Chain{d: d}
+}
+
+// Key returns a chain with the map key added to the path.
+func (c *Chain) Key(key string) *Chain {
+	return &Chain{d: c.d, path: appendPath(c.path, key)}
+}
+
+// Index returns a chain with the slice index added to the path.
+func (c *Chain) Index(i int) *Chain {
+	return &Chain{d: c.d, path: appendPath(c.path, i)}
+}
+
+// Path returns the path built by the chain.
+func (c *Chain) Path() []interface{} {
+	return appendPath(c.path)
+}
+
+// DMap returns the data at the path of the chain, like Get.
+func (c *Chain) DMap() (*DMap, error) {
+	return c.d.Get(c.path...)
+}
+
+// String returns the string at the path of the chain, like GetString.
+func (c *Chain) String() (string, error) {
+	return c.d.GetString(c.path...)
+}
+
+// Bool returns the bool at the path of the chain, like GetBool.
+func (c *Chain) Bool() (bool, error) {
+	return c.d.GetBool(c.path...)
+}
+
+// Int returns the number at the path of the chain as an int, like GetInt.
+func (c *Chain) Int() (int, error) {
+	return c.d.GetInt(c.path...)
+}
+
+// Int64 returns the number at the path of the chain as an int64, like GetInt64.
+func (c *Chain) Int64() (int64, error) {
+	return c.d.GetInt64(c.path...)
+}
+
+// Float64 returns the number at the path of the chain as a float64, like GetFloat64.
+func (c *Chain) Float64() (float64, error) {
+	return c.d.GetFloat64(c.path...)
+}
+
+// MapSI returns the map at the path of the chain, like GetMapSI.
+func (c *Chain) MapSI() (map[string]interface{}, error) {
+	return c.d.GetMapSI(c.path...)
+}
+
+// SliceI returns the []interface{} at the path of the chain, like GetSliceI.
+func (c *Chain) SliceI() ([]interface{}, error) {
+	return c.d.GetSliceI(c.path...)
+}
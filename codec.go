@@ -0,0 +1,57 @@
+package dmap
+
+import (
+	"encoding/json"
+)
+
+// Codec converts data between a serialization format and the maps, slices and scalars stored by a dmap. Implement it to parse and encode formats which the package does not support.
+type Codec interface {
+	Decode(b []byte) (interface{}, error)
+	Encode(v interface{}) ([]byte, error)
+}
+
+// JSONCodec is a Codec for JSON. It decodes like ParseJSONBytes and encodes interface-keyed maps like ToMapSI converts them.
+var JSONCodec Codec = jsonCodec{}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Decode(b []byte) (interface{}, error) {
+	d, err := defaultParser.ParseBytes(b)
+	if err != nil {
+		return nil, err
+	}
+	return d.Data(), nil
+}
+
+func (jsonCodec) Encode(v interface{}) ([]byte, error) {
+	v, err := stringKeys(v, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+// Parse returns a new dmap with the bytes decoded by the codec.
+func Parse(codec Codec, b []byte) (*DMap, error) {
+	v, err := codec.Decode(b)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DMap{data: v}, nil
+}
+
+// Encode returns the data of the dmap encoded by the codec.
+func (d *DMap) Encode(codec Codec) ([]byte, error) {
+	return codec.Encode(d.Data())
+}
+
+// GetJSONRaw returns the data at a given path encoded as JSON by JSONCodec, for forwarding a part of the document without encoding all of it. The keys of interface-keyed maps are converted like in ToMapSI.
+func (d *DMap) GetJSONRaw(path ...interface{}) (json.RawMessage, error) {
+	data, err := d.Get(path...)
+	if err != nil {
+		return nil, err
+	}
+
+	return data.Encode(JSONCodec)
+}
@@ -0,0 +1,248 @@
+package dmap
+
+import (
+	"encoding/base64"
+	"fmt"
+	"time"
+)
+
+var (
+	errorKeyCollision = "keys %+v and %+v both convert to %q at path %v"
+)
+
+// GetMapAsSI returns the map at a given path as map[string]interface{}. A map[interface{}]interface{} is converted to a new map with every key formatted using fmt.Sprint, and an error is returned if two keys format to the same string. An *OrderedMap is converted to a new map as well.
+// A map[string]interface{} is returned as it is, so only that case shares the map with the dmap.
+func (d *DMap) GetMapAsSI(path ...interface{}) (map[string]interface{}, error) {
+	data, err := d.Get(path...)
+	if err != nil {
+		return nil, err
+	}
+
+	switch m := data.Data().(type) {
+	case map[string]interface{}:
+		return m, nil
+
+	case map[interface{}]interface{}:
+		return mapIItoSI(m, path)
+
+	case *OrderedMap:
+		return m.toMapSI(), nil
+	}
+
+	return nil, fmt.Errorf(errorNotMap, path)
+}
+
+// GetMapAsII returns the map at a given path as map[interface{}]interface{}. A map[string]interface{} or an *OrderedMap is converted to a new map with the same keys.
+// A map[interface{}]interface{} is returned as it is, so only that case shares the map with the dmap.
+func (d *DMap) GetMapAsII(path ...interface{}) (map[interface{}]interface{}, error) {
+	data, err := d.Get(path...)
+	if err != nil {
+		return nil, err
+	}
+
+	switch m := data.Data().(type) {
+	case map[interface{}]interface{}:
+		return m, nil
+
+	case map[string]interface{}:
+		mapII := make(map[interface{}]interface{}, len(m))
+		for k, v := range m {
+			mapII[k] = v
+		}
+		return mapII, nil
+
+	case *OrderedMap:
+		mapII := make(map[interface{}]interface{}, m.Len())
+		for k, v := range m.values {
+			mapII[k] = v
+		}
+		return mapII, nil
+	}
+
+	return nil, fmt.Errorf(errorNotMap, path)
+}
+
+// ToMapSI returns a copy of the whole data as map[string]interface{}, in which every nested map[interface{}]interface{} or *OrderedMap is converted to map[string]interface{} as well, with the keys converted like in GetMapAsSI. The data at the root has to be a map.
+func (d *DMap) ToMapSI() (map[string]interface{}, error) {
+	if !isMap(d.Data()) {
+		return nil, fmt.Errorf(errorNotMap, []interface{}{})
+	}
+
+	v, err := stringKeys(d.Data(), nil, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.(map[string]interface{}), nil
+}
+
+// AsStringMap returns a copy of the map at a given path as map[string]interface{}, converting every nested map like ToMapSI does, so that it only holds string-keyed maps, slices and scalars. Keys are formatted with fmt.Sprint, and an error is returned if two keys of a map format to the same string.
+func (d *DMap) AsStringMap(path ...interface{}) (map[string]interface{}, error) {
+	data, err := d.Get(path...)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isMap(data.Data()) {
+		return nil, fmt.Errorf(errorNotMap, path)
+	}
+
+	v, err := stringKeys(data.Data(), path, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.(map[string]interface{}), nil
+}
+
+// DuplicateStringKeys returns the paths of the keys of interface-keyed maps which format to the same string with fmt.Sprint as another key of their map, like 1 and "1", and so make ToMapSI and the other conversions to string keys fail. The paths are in the order of Walk, with the keys of a map which collide next to each other. An error is only returned if the dmap has no data.
+func (d *DMap) DuplicateStringKeys() ([][]interface{}, error) {
+	if !d.HasData() {
+		return nil, fmt.Errorf(errorEmptyData)
+	}
+
+	var paths [][]interface{}
+	walk(d.Data(), nil, func(path []interface{}, v interface{}) error {
+		m, ok := v.(map[interface{}]interface{})
+		if !ok {
+			return nil
+		}
+
+		var names []string
+		keys := map[string][]interface{}{}
+		for _, k := range sortedKeys(m) {
+			name := fmt.Sprint(k)
+			if _, ok := keys[name]; !ok {
+				names = append(names, name)
+			}
+			keys[name] = append(keys[name], k)
+		}
+
+		for _, name := range names {
+			if len(keys[name]) < 2 {
+				continue
+			}
+			for _, k := range keys[name] {
+				paths = append(paths, appendPath(path, k))
+			}
+		}
+
+		return nil
+	})
+
+	return paths, nil
+}
+
+// StringifyValues returns a new dmap with a deep copy of the data in which the values which JSON has no type for are converted to strings, like before encoding data decoded from another format as JSON. A time.Time is converted to RFC 3339, with the fractional seconds when there are any, and a []byte to standard base64. The maps keep their types, so interface-keyed maps are only marshallable after ToMapSI.
+func (d *DMap) StringifyValues() *DMap {
+	return &DMap{data: stringifyValues(d.Data())}
+}
+
+// stringifyValues returns a copy of v with its values converted like in StringifyValues.
+func stringifyValues(v interface{}) interface{} {
+	switch v := v.(type) {
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+
+	case []byte:
+		return base64.StdEncoding.EncodeToString(v)
+
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, elem := range v {
+			m[k] = stringifyValues(elem)
+		}
+		return m
+
+	case map[interface{}]interface{}:
+		m := make(map[interface{}]interface{}, len(v))
+		for k, elem := range v {
+			m[k] = stringifyValues(elem)
+		}
+		return m
+
+	case *OrderedMap:
+		m := NewOrderedMap()
+		for _, k := range v.keys {
+			m.Set(k, stringifyValues(v.values[k]))
+		}
+		return m
+
+	case []interface{}:
+		s := make([]interface{}, len(v))
+		for i, elem := range v {
+			s[i] = stringifyValues(elem)
+		}
+		return s
+	}
+
+	return v
+}
+
+// stringKeys returns a copy of v in which every map is a map[string]interface{}, with the keys of the interface-keyed maps converted like in mapIItoSI. If keepOrdered is true, an *OrderedMap is returned as it is since it marshals its values itself, otherwise it is converted as well. The path of v is used for errors.
+func stringKeys(v interface{}, path []interface{}, keepOrdered bool) (interface{}, error) {
+	switch v := v.(type) {
+	case *OrderedMap:
+		if keepOrdered {
+			return v, nil
+		}
+		return stringKeys(v.toMapSI(), path, false)
+
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, elem := range v {
+			converted, err := stringKeys(elem, appendPath(path, k), keepOrdered)
+			if err != nil {
+				return nil, err
+			}
+			m[k] = converted
+		}
+		return m, nil
+
+	case map[interface{}]interface{}:
+		m, err := mapIItoSI(v, path)
+		if err != nil {
+			return nil, err
+		}
+
+		for k, elem := range v {
+			converted, err := stringKeys(elem, appendPath(path, k), keepOrdered)
+			if err != nil {
+				return nil, err
+			}
+			m[fmt.Sprint(k)] = converted
+		}
+		return m, nil
+
+	case []interface{}:
+		s := make([]interface{}, len(v))
+		for i, elem := range v {
+			converted, err := stringKeys(elem, appendPath(path, i), keepOrdered)
+			if err != nil {
+				return nil, err
+			}
+			s[i] = converted
+		}
+		return s, nil
+	}
+
+	return v, nil
+}
+
+// mapIItoSI returns a map[string]interface{} with the entries of m, formatting the keys with fmt.Sprint. The path of m is used for errors.
+func mapIItoSI(m map[interface{}]interface{}, path []interface{}) (map[string]interface{}, error) {
+	mapSI := make(map[string]interface{}, len(m))
+	keys := make(map[string]interface{}, len(m))
+
+	for k, v := range m {
+		key := fmt.Sprint(k)
+		if other, ok := keys[key]; ok {
+			return nil, fmt.Errorf(errorKeyCollision, other, k, key, path)
+		}
+
+		keys[key] = k
+		mapSI[key] = v
+	}
+
+	return mapSI, nil
+}
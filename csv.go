@@ -0,0 +1,69 @@
+package dmap
+
+import (
+	"encoding/csv"
+	"io"
+	"math"
+	"strconv"
+)
+
+// ParseCSV returns a new dmap with the CSV rows read from the reader as a []interface{} of map[string]interface{}. If header is true the first row is used as the keys of every map, otherwise the keys are the column numbers as strings, starting at "0". All the values are kept as strings.
+func ParseCSV(r io.Reader, header bool) (*DMap, error) {
+	return parseCSV(r, header, false)
+}
+
+// ParseCSVInfer is like ParseCSV, but converts the values which look like numbers to float64 and the values "true" and "false" to bool, matching the types produced by the JSON parser. Any other value, including NaN and infinities, is kept as a string.
+func ParseCSVInfer(r io.Reader, header bool) (*DMap, error) {
+	return parseCSV(r, header, true)
+}
+
+func parseCSV(r io.Reader, header bool, infer bool) (*DMap, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	if header && len(records) > 0 {
+		keys = records[0]
+		records = records[1:]
+	}
+
+	rows := make([]interface{}, len(records))
+	for i, record := range records {
+		row := make(map[string]interface{}, len(record))
+		for j, field := range record {
+			var key string
+			if j < len(keys) {
+				key = keys[j]
+			} else {
+				key = strconv.Itoa(j)
+			}
+
+			if infer {
+				row[key] = inferCSVValue(field)
+			} else {
+				row[key] = field
+			}
+		}
+		rows[i] = row
+	}
+
+	return &DMap{data: rows}, nil
+}
+
+func inferCSVValue(s string) interface{} {
+	switch s {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return s
+	}
+
+	return f
+}
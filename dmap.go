@@ -1,9 +1,11 @@
 package dmap
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"strconv"
+	"strings"
 )
 
 var (
@@ -15,12 +17,43 @@ var (
 	errorUnexpectedType  = "data at %v is not a map or slice"
 	errorNotMapSI        = "data at %v is not a map[string]interface{}"
 	errorNotMapII        = "data at %v is not a map[interface{}]interface{}"
+	errorNonStringKey    = "key %+v of type %T at path %v is not a string"
 	errorNotSliceI       = "data at %v is not a []interface{}"
+	errorNotMap          = "data at %v is not a map"
+	errorNotString       = "data at %v is not a string"
+	errorNotStringSlice  = "data at %v is not a string or a []interface{} of strings"
+	errorNoKeyFound      = "none of the keys %v found at path %v"
+	errorNoPathFound     = "none of the paths %v exists"
+	errorSeveralPaths    = "only one of the paths %v can exist, found %v"
 )
 
+// ErrReadOnly is returned by the methods which modify the data when they are called on a frozen dmap.
+var ErrReadOnly = errors.New("dmap is read-only")
+
 // DMap stores the data and provides a bunch of methods to access and manipulate it.
+// A nil *DMap behaves like a dmap without any data: Data returns nil, HasData returns false and Get returns an empty data error for any non-empty path.
+// The dmaps returned by the methods share their data with the dmap unless the method says otherwise, so changes made through them are visible in it.
 type DMap struct {
 	data interface{}
+
+	// options holds the settings which most dmaps never have, so that the dmaps returned by Get stay small. It is nil when none is set, and it is shared with the dmaps returned by wrap, so it is replaced instead of modified when a setting changes.
+	options *dmapOptions
+}
+
+// dmapOptions holds the settings of a dmap besides its data.
+type dmapOptions struct {
+	readOnly  bool
+	errorHook func(path []interface{}, err error)
+
+	// disallowUnknownFields is set for the dmaps parsed by a Parser with DisallowUnknownFields, and makes GetInto strict.
+	disallowUnknownFields bool
+
+	// watchers holds the callbacks registered with OnChange.
+	watchers []func(path []interface{}, oldVal, newVal interface{})
+
+	// parent and parentPath are set for the dmaps returned by Sub, so that replacing their data also replaces it in the parent. They are not carried over by wrap.
+	parent     *DMap
+	parentPath []interface{}
 }
 
 // Init returns a new dmap with the data passed as argument.
@@ -32,41 +65,84 @@ func Init(v interface{}) *DMap {
 
 // ParseJSONBytes returns a new dmap with the JSON bytes unmarshalled.
 func ParseJSONBytes(jsonBytes []byte) (*DMap, error) {
-	var v interface{}
-	err := json.Unmarshal(jsonBytes, &v)
+	return defaultParser.ParseBytes(jsonBytes)
+}
+
+// ParseJSONBuffer retuns a new dmap with the JSON buffer unmarshalled.
+func ParseJSONBuffer(jsonBuffer io.Reader) (*DMap, error) {
+	return defaultParser.ParseBuffer(jsonBuffer)
+}
+
+// ParseJSONMulti returns a new dmap for every JSON value read from the buffer until its end, like {"a":1}{"a":2}. If a value cannot be decoded, the dmaps of the values before it are returned along with the error.
+func ParseJSONMulti(jsonBuffer io.Reader) ([]*DMap, error) {
+	return defaultParser.ParseMulti(jsonBuffer)
+}
+
+// MustParseJSON is like ParseJSONBytes, but panics if the JSON bytes cannot be parsed, like regexp.MustCompile. It is meant for known-good JSON in tests and in the initialization of package variables, not for data read at runtime.
+func MustParseJSON(jsonBytes []byte) *DMap {
+	d, err := ParseJSONBytes(jsonBytes)
 	if err != nil {
-		return nil, err
+		panic("dmap: MustParseJSON: " + err.Error())
 	}
 
-	return &DMap{data: v}, nil
+	return d
 }
 
-// ParseJSONBuffer retuns a new dmap with the JSON buffer unmarshalled.
-func ParseJSONBuffer(jsonBuffer io.Reader) (*DMap, error) {
-	var v interface{}
-	decoder := json.NewDecoder(jsonBuffer)
-	err := decoder.Decode(&v)
+// MustParseJSONString is like MustParseJSON, but takes the JSON as a string.
+func MustParseJSONString(jsonString string) *DMap {
+	d, err := ParseJSONBytes([]byte(jsonString))
 	if err != nil {
-		return nil, err
+		panic("dmap: MustParseJSONString: " + err.Error())
 	}
 
-	return &DMap{data: v}, nil
+	return d
+}
+
+// Freeze returns a read-only view sharing the data of the dmap, whose modifying methods return ErrReadOnly and whose results are frozen too.
+func (d *DMap) Freeze() *DMap {
+	frozen := d.wrap(d.Data())
+	frozen.setOptions(func(o *dmapOptions) { o.readOnly = true })
+	return frozen
+}
+
+// WithErrorHook returns a view of the dmap which calls fn with the path and the error whenever getting data at a path fails. The returned error is not changed by the hook, and the dmaps returned by the view call the hook as well.
+// Probing a path, like with Lookup, Exists or the functions built on them, does not call the hook.
+func (d *DMap) WithErrorHook(fn func(path []interface{}, err error)) *DMap {
+	hooked := d.wrap(d.Data())
+	hooked.setOptions(func(o *dmapOptions) { o.errorHook = fn })
+	return hooked
+}
+
+// OnChange registers fn to be called with the path and values of every change made through the dmap or the dmaps it returns afterwards.
+func (d *DMap) OnChange(fn func(path []interface{}, oldVal, newVal interface{})) {
+	d.setOptions(func(o *dmapOptions) {
+		watchers := make([]func(path []interface{}, oldVal, newVal interface{}), 0, len(o.watchers)+1)
+		o.watchers = append(append(watchers, o.watchers...), fn)
+	})
 }
 
-// Data returns the data stored by the dmap.
+// Data returns the data stored by the dmap. It returns nil for a nil dmap.
 func (d *DMap) Data() interface{} {
+	if d == nil {
+		return nil
+	}
 	return d.data
 }
 
-// HasData checks if the dmap has any data.
+// HasData checks if the dmap has any data. It returns false for a nil dmap.
 func (d *DMap) HasData() bool {
-	return d.data != nil
+	return d.Data() != nil
+}
+
+// Depth returns the maximum nesting depth of the data: 0 for a scalar, 1 for a map or slice containing only scalars, and so on. Empty maps and slices count as one level.
+func (d *DMap) Depth() int {
+	return depth(d.Data())
 }
 
-// Get returns the data at a given path. May return a key missing or index out of range error.
+// Get returns the data at a given path. May return a key missing or index out of range error. It is safe to call on a nil dmap.
 func (d *DMap) Get(path ...interface{}) (*DMap, error) {
 	if !d.HasData() && len(path) != 0 {
-		return nil, fmt.Errorf(errorEmptyData)
+		return nil, d.fail(append([]interface{}(nil), path...), fmt.Errorf(errorEmptyData))
 	}
 
 	currentData := d.Data()
@@ -75,12 +151,12 @@ func (d *DMap) Get(path ...interface{}) (*DMap, error) {
 		if data, ok := currentData.(map[string]interface{}); ok {
 			key, ok := p.(string)
 			if !ok {
-				return nil, fmt.Errorf(errorExpectedKey, p, p, path[:i+1])
+				return nil, d.failAt(path, i, errorExpectedKey, p, p)
 			}
 
 			v, ok := data[key]
 			if !ok {
-				return nil, fmt.Errorf(errorKeyNotFound, key, path[:i+1])
+				return nil, d.failAt(path, i, errorKeyNotFound, p)
 			}
 
 			currentData = v
@@ -88,7 +164,20 @@ func (d *DMap) Get(path ...interface{}) (*DMap, error) {
 		} else if data, ok := currentData.(map[interface{}]interface{}); ok {
 			v, ok := data[p]
 			if !ok {
-				return nil, fmt.Errorf(errorKeyNotFound, p, path[:i+1])
+				return nil, d.failAt(path, i, errorKeyNotFound, p)
+			}
+
+			currentData = v
+
+		} else if data, ok := currentData.(*OrderedMap); ok {
+			key, ok := p.(string)
+			if !ok {
+				return nil, d.failAt(path, i, errorExpectedKey, p, p)
+			}
+
+			v, ok := data.Value(key)
+			if !ok {
+				return nil, d.failAt(path, i, errorKeyNotFound, p)
 			}
 
 			currentData = v
@@ -96,36 +185,312 @@ func (d *DMap) Get(path ...interface{}) (*DMap, error) {
 		} else if data, ok := currentData.([]interface{}); ok {
 			index, ok := p.(int)
 			if !ok {
-				return nil, fmt.Errorf(errorExpectedIndex, p, p, path[:i+1])
+				return nil, d.failAt(path, i, errorExpectedIndex, p, p)
 			}
 
 			if index < 0 || index >= len(data) {
-				return nil, fmt.Errorf(errorIndexOutOfRange, index, path[:i+1])
+				return nil, d.failAt(path, i, errorIndexOutOfRange, p)
 			}
 
 			currentData = data[index]
 
 		} else {
-			return nil, fmt.Errorf(errorUnexpectedType, path[:i+1])
+			return nil, d.failAt(path, i, errorUnexpectedType)
+		}
+	}
+
+	return d.wrap(currentData), nil
+}
+
+// GetSlicePath is like Get, but takes the path as a slice, which is passed along without being copied.
+func (d *DMap) GetSlicePath(path []interface{}) (*DMap, error) {
+	return d.Get(path...)
+}
+
+// GetFast returns the data at a given path of string keys. It is a faster Get for data made of map[string]interface{}: as long as the maps along the path are string-keyed, the keys are looked up directly. When it reaches any other kind of data it continues like Get, and it returns the same errors as Get.
+func (d *DMap) GetFast(path ...string) (*DMap, error) {
+	if !d.HasData() && len(path) != 0 {
+		return nil, d.fail(stringsToPath(path), fmt.Errorf(errorEmptyData))
+	}
+
+	currentData := d.Data()
+
+	for i, key := range path {
+		data, ok := currentData.(map[string]interface{})
+		if !ok {
+			return d.Get(stringsToPath(path)...)
+		}
+
+		v, ok := data[key]
+		if !ok {
+			err := &keyNotFoundError{path: append([]string(nil), path[:i+1]...)}
+			if d.options != nil && d.options.errorHook != nil {
+				d.fail(stringsToPath(path), err)
+			}
+			return nil, err
+		}
+
+		currentData = v
+	}
+
+	return d.wrap(currentData), nil
+}
+
+// getError is an error of Get, whose message is only formatted when it is read, as the callers probing for paths often discard it.
+type getError struct {
+	format string
+	args   []interface{}
+}
+
+func (e *getError) Error() string {
+	return fmt.Sprintf(e.format, e.args...)
+}
+
+// missing checks if the error is for a key or an index which is not in the data.
+func (e *getError) missing() bool {
+	return e.format == errorKeyNotFound || e.format == errorIndexOutOfRange
+}
+
+// keyNotFoundError is the error returned by GetFast for a missing key, whose message is only formatted when it is read.
+type keyNotFoundError struct {
+	path []string
+}
+
+func (e *keyNotFoundError) Error() string {
+	return fmt.Sprintf(errorKeyNotFound, e.path[len(e.path)-1], stringsToPath(e.path))
+}
+
+// stringsToPath converts a path of string keys to a path which can be passed to Get.
+func stringsToPath(keys []string) []interface{} {
+	path := make([]interface{}, len(keys))
+	for i, key := range keys {
+		path[i] = key
+	}
+	return path
+}
+
+// GetByString returns the data at a path written as a string, with the keys or indices separated by dots, like "root.contents.1". A part is used as an index when the data it is applied to is a slice, and as a string key otherwise. An empty string refers to the whole data.
+func (d *DMap) GetByString(path string) (*DMap, error) {
+	return d.Get(d.parseStringPath(path)...)
+}
+
+// parseStringPath splits a dotted path and converts the parts which are applied to slices into indices.
+func (d *DMap) parseStringPath(s string) []interface{} {
+	if s == "" {
+		return nil
+	}
+
+	parts := strings.Split(s, ".")
+	path := make([]interface{}, len(parts))
+	currentData := d.Data()
+
+	for i, part := range parts {
+		path[i] = part
+
+		if data, ok := currentData.([]interface{}); ok {
+			index, err := strconv.Atoi(part)
+			if err != nil {
+				currentData = nil
+				continue
+			}
+
+			path[i] = index
+			if index >= 0 && index < len(data) {
+				currentData = data[index]
+			} else {
+				currentData = nil
+			}
+		} else {
+			currentData, _ = mapValue(currentData, part)
 		}
 	}
 
-	return &DMap{currentData}, nil
+	return path
+}
+
+// Lookup returns the data at a given path and whether it exists, in a single traversal. Use Get when the reason of a failure is needed.
+func (d *DMap) Lookup(path ...interface{}) (*DMap, bool) {
+	data, err := (&DMap{data: d.Data()}).Get(path...)
+	if err != nil {
+		return nil, false
+	}
+	return d.wrap(data.Data()), true
 }
 
 // Exists checks whether there is some data at a given path and returns a boolean value.
 func (d *DMap) Exists(path ...interface{}) bool {
-	_, err := d.Get(path...)
+	_, err := (&DMap{data: d.Data()}).Get(path...)
 	return err == nil
 }
 
-// GetMapSI returns the data at a given path as map[string]interface{}.
+// AllExist checks whether there is some data at every one of the given paths.
+func (d *DMap) AllExist(paths ...[]interface{}) bool {
+	for _, path := range paths {
+		if !d.Exists(path...) {
+			return false
+		}
+	}
+	return true
+}
+
+// AnyExist checks whether there is some data at any of the given paths.
+func (d *DMap) AnyExist(paths ...[]interface{}) bool {
+	for _, path := range paths {
+		if d.Exists(path...) {
+			return true
+		}
+	}
+	return false
+}
+
+// MissingPaths returns the given paths which do not exist, in the order they were passed.
+func (d *DMap) MissingPaths(paths ...[]interface{}) [][]interface{} {
+	var missing [][]interface{}
+	for _, path := range paths {
+		if !d.Exists(path...) {
+			missing = append(missing, path)
+		}
+	}
+	return missing
+}
+
+// ExactlyOne returns the only one of the given paths at which there is some data, for options which exclude each other. It returns an error if there is data at none of the paths, or at several of them, naming the ones found.
+func (d *DMap) ExactlyOne(paths ...[]interface{}) ([]interface{}, error) {
+	var found [][]interface{}
+	for _, path := range paths {
+		if d.Exists(path...) {
+			found = append(found, path)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf(errorNoPathFound, paths)
+	case 1:
+		return found[0], nil
+	}
+
+	return nil, fmt.Errorf(errorSeveralPaths, paths, found)
+}
+
+// GetKey returns the value of key in the map at a given path. Unlike Get, it returns an error if the data at the path is not a map.
+func (d *DMap) GetKey(key string, path ...interface{}) (*DMap, error) {
+	data, err := d.Get(path...)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isMap(data.Data()) {
+		return nil, d.fail(appendPath(path, key), fmt.Errorf(errorNotMap, path))
+	}
+
+	v, ok := mapValue(data.Data(), key)
+	if !ok {
+		return nil, d.fail(appendPath(path, key), fmt.Errorf(errorKeyNotFound, key, appendPath(path, key)))
+	}
+
+	return d.wrap(v), nil
+}
+
+// GetFold returns the value of the map at a given path whose key matches key under Unicode case folding, as in strings.EqualFold. If several keys match, a key equal to key is preferred, and otherwise the first matching key in sorted order is used. Keys which are not strings never match.
+func (d *DMap) GetFold(key string, path ...interface{}) (*DMap, error) {
+	data, err := d.Get(path...)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isMap(data.Data()) {
+		return nil, d.fail(appendPath(path, key), fmt.Errorf(errorNotMap, path))
+	}
+
+	if v, ok := mapValue(data.Data(), key); ok {
+		return d.wrap(v), nil
+	}
+
+	for _, k := range sortedKeys(data.Data()) {
+		if s, ok := k.(string); ok && strings.EqualFold(s, key) {
+			v, _ := mapValue(data.Data(), k)
+			return d.wrap(v), nil
+		}
+	}
+
+	return nil, d.fail(appendPath(path, key), fmt.Errorf(errorKeyNotFound, key, appendPath(path, key)))
+}
+
+// GetAlias returns the value of the first of keys present in the map at a given path, for a value which may be stored under one of several spellings. It returns an error if the data at the path is not a map or if none of the keys is present.
+func (d *DMap) GetAlias(keys []string, path ...interface{}) (*DMap, error) {
+	data, err := d.Get(path...)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isMap(data.Data()) {
+		return nil, d.fail(path, fmt.Errorf(errorNotMap, path))
+	}
+
+	for _, key := range keys {
+		if v, ok := mapValue(data.Data(), key); ok {
+			return d.wrap(v), nil
+		}
+	}
+
+	return nil, d.fail(path, fmt.Errorf(errorNoKeyFound, keys, path))
+}
+
+// GetIndex returns the element at index i of the []interface{} at a given path. Unlike Get, it returns an error if the data at the path is not a slice.
+func (d *DMap) GetIndex(i int, path ...interface{}) (*DMap, error) {
+	parent, err := d.Get(path...)
+	if err != nil {
+		return nil, err
+	}
+
+	data, ok := parent.Data().([]interface{})
+	if !ok {
+		return nil, d.fail(appendPath(path, i), fmt.Errorf(errorNotSliceI, path))
+	}
+
+	if i < 0 || i >= len(data) {
+		return nil, d.fail(appendPath(path, i), fmt.Errorf(errorIndexOutOfRange, i, appendPath(path, i)))
+	}
+
+	return d.wrap(data[i]), nil
+}
+
+// Placeholder stands for an index in the path templates passed to GetAt.
+var Placeholder = placeholder{}
+
+type placeholder struct{}
+
+// GetAt returns the data at the path template with every Placeholder replaced by index, like GetAt(i, "items", Placeholder, "name") for the name of the i-th item.
+func (d *DMap) GetAt(index int, template ...interface{}) (*DMap, error) {
+	path := make([]interface{}, len(template))
+	for i, p := range template {
+		if p == Placeholder {
+			p = index
+		}
+		path[i] = p
+	}
+
+	return d.Get(path...)
+}
+
+// GetMapSI returns the data at a given path as map[string]interface{}. A map[interface{}]interface{} is converted to a new map[string]interface{}, as long as all of its keys are strings, and so is an *OrderedMap; modifying a converted map does not change the dmap.
 func (d *DMap) GetMapSI(path ...interface{}) (map[string]interface{}, error) {
 	data, err := d.Get(path...)
 	if err != nil {
 		return nil, err
 	}
 
+	return asMapSI(data.Data(), path)
+}
+
+// StrictMapSI returns the data at a given path as map[string]interface{}. Unlike GetMapSI, it does not convert a map[interface{}]interface{}.
+func (d *DMap) StrictMapSI(path ...interface{}) (map[string]interface{}, error) {
+	data, err := d.Get(path...)
+	if err != nil {
+		return nil, err
+	}
+
 	dataMapSI, ok := data.Data().(map[string]interface{})
 	if !ok {
 		return nil, fmt.Errorf(errorNotMapSI, path)
@@ -164,32 +529,162 @@ func (d *DMap) GetSliceI(path ...interface{}) ([]interface{}, error) {
 	return dataSliceI, nil
 }
 
+// GetSliceIOrEmpty is like GetSliceI, but returns an empty, non-nil slice when a key or an index of the path is missing, for looping over optional lists.
+// Data of another type on the path or at it, including nil, still returns an error, so a mistyped value is not mistaken for a missing one.
+func (d *DMap) GetSliceIOrEmpty(path ...interface{}) ([]interface{}, error) {
+	data, err := (&DMap{data: d.Data()}).Get(path...)
+	var getErr *getError
+	if errors.As(err, &getErr) && getErr.missing() {
+		return []interface{}{}, nil
+	}
+	if err != nil {
+		return nil, d.fail(path, err)
+	}
+
+	dataSliceI, ok := data.Data().([]interface{})
+	if !ok {
+		return nil, fmt.Errorf(errorNotSliceI, path)
+	}
+
+	return dataSliceI, nil
+}
+
+// GetDMapSlice returns the []interface{} at a given path with every element wrapped in a dmap. The dmaps share their data with the slice, so changes made through them are visible in the dmap.
+func (d *DMap) GetDMapSlice(path ...interface{}) ([]*DMap, error) {
+	data, err := d.GetSliceI(path...)
+	if err != nil {
+		return nil, err
+	}
+
+	dmaps := make([]*DMap, len(data))
+	for i, v := range data {
+		dmaps[i] = d.wrap(v)
+	}
+
+	return dmaps, nil
+}
+
+// GetDMapMap returns the map[string]interface{} at a given path with every value wrapped in a dmap. The dmaps share their data with the map, so changes made through them are visible in the dmap.
+func (d *DMap) GetDMapMap(path ...interface{}) (map[string]*DMap, error) {
+	data, err := d.GetMapSI(path...)
+	if err != nil {
+		return nil, err
+	}
+
+	dmaps := make(map[string]*DMap, len(data))
+	for k, v := range data {
+		dmaps[k] = d.wrap(v)
+	}
+
+	return dmaps, nil
+}
+
+// GetTable returns the []interface{} at a given path as a slice of map[string]interface{}. Every element has to be a map, and interface-keyed maps are converted like in GetMapSI.
+func (d *DMap) GetTable(path ...interface{}) ([]map[string]interface{}, error) {
+	data, err := d.GetSliceI(path...)
+	if err != nil {
+		return nil, err
+	}
+
+	table := make([]map[string]interface{}, len(data))
+	for i, v := range data {
+		row, err := asMapSI(v, appendPath(path, i))
+		if err != nil {
+			return nil, err
+		}
+		table[i] = row
+	}
+
+	return table, nil
+}
+
+// GetStringOrSlice returns the data at a given path as []string. A string is returned as a slice with a single element, and a []interface{} has to contain only strings.
+func (d *DMap) GetStringOrSlice(path ...interface{}) ([]string, error) {
+	data, err := d.Get(path...)
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := data.Data().(type) {
+	case string:
+		return []string{v}, nil
+
+	case []interface{}:
+		strs := make([]string, len(v))
+		for i, elem := range v {
+			s, ok := elem.(string)
+			if !ok {
+				return nil, fmt.Errorf(errorNotString, appendPath(path, i))
+			}
+			strs[i] = s
+		}
+		return strs, nil
+	}
+
+	return nil, fmt.Errorf(errorNotStringSlice, path)
+}
+
+// GetValues returns the one or many strings at a given path, like for the values of an HTTP header. It is the same as GetStringOrSlice.
+func (d *DMap) GetValues(path ...interface{}) ([]string, error) {
+	return d.GetStringOrSlice(path...)
+}
+
+// GetFirstValue returns the first of the strings returned by GetValues, and an error if there are none.
+func (d *DMap) GetFirstValue(path ...interface{}) (string, error) {
+	values, err := d.GetValues(path...)
+	if err != nil {
+		return "", err
+	}
+
+	if len(values) == 0 {
+		return "", fmt.Errorf(errorEmptySlice, path)
+	}
+
+	return values[0], nil
+}
+
 // SetMapSI sets data to a map[string]interface{} at a given path. The path has to already exist - new keys or indices will not be added.
 func (d *DMap) SetMapSI(data interface{}, key string, path ...interface{}) error {
-	parent, err := d.GetMapSI(path...)
+	if d.isReadOnly() {
+		return ErrReadOnly
+	}
+
+	parent, err := d.StrictMapSI(path...)
 	if err != nil {
 		return err
 	}
 
+	old := parent[key]
 	parent[key] = data
+	d.notify(appendPath(path, key), old, data)
 
 	return nil
 }
 
 // SetMapII sets data to a map[interface{}]interface{} at a given path. The path has to already exist - new keys or indices will not be added.
 func (d *DMap) SetMapII(data interface{}, key interface{}, path ...interface{}) error {
+	if d.isReadOnly() {
+		return ErrReadOnly
+	}
+
 	parent, err := d.GetMapII(path...)
 	if err != nil {
 		return err
 	}
 
+	old := parent[key]
 	parent[key] = data
+	d.notify(appendPath(path, key), old, data)
 
 	return nil
 }
 
 // SetSliceI sets data to a []interface{} at a given path. The path has to already exist - new keys or indices will not be added.
 func (d *DMap) SetSliceI(data interface{}, index int, path ...interface{}) error {
+	if d.isReadOnly() {
+		return ErrReadOnly
+	}
+
 	parent, err := d.GetSliceI(path...)
 	if err != nil {
 		return err
@@ -199,7 +694,661 @@ func (d *DMap) SetSliceI(data interface{}, index int, path ...interface{}) error
 		return fmt.Errorf(errorIndexOutOfRange, index, path)
 	}
 
+	old := parent[index]
 	parent[index] = data
+	d.notify(appendPath(path, index), old, data)
 
 	return nil
 }
+
+// ConcatSlice appends all the values to the []interface{} at a given path at once, and stores the grown slice back at the path. An empty path appends to the slice at the root.
+func (d *DMap) ConcatSlice(values []interface{}, path ...interface{}) error {
+	if d.isReadOnly() {
+		return ErrReadOnly
+	}
+
+	data, err := d.GetSliceI(path...)
+	if err != nil {
+		return err
+	}
+
+	return d.setAt(append(data, values...), path)
+}
+
+// AppendUnique appends value to the []interface{} at a given path unless an element equal to it is already there, and returns whether it was appended. Values are compared like in SliceContains.
+func (d *DMap) AppendUnique(value interface{}, path ...interface{}) (bool, error) {
+	if d.isReadOnly() {
+		return false, ErrReadOnly
+	}
+
+	data, err := d.GetSliceI(path...)
+	if err != nil {
+		return false, err
+	}
+
+	if sliceContains(data, value) {
+		return false, nil
+	}
+
+	err = d.setAt(append(data, value), path)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Sub returns the data at a given path as a dmap which remembers where it came from. Setting data inside it works like for any dmap returned by Get, since the maps and slices are shared with the parent.
+// Replace on the returned dmap also stores the new data at the path in the parent, so the parent sees the replacement even when the data at the path is a scalar. The path is not tracked afterwards, so if the parent data is restructured, Replace writes to whatever is at the path at that time.
+func (d *DMap) Sub(path ...interface{}) (*DMap, error) {
+	data, err := d.Get(path...)
+	if err != nil {
+		return nil, err
+	}
+
+	data.setOptions(func(o *dmapOptions) {
+		o.parent = d
+		o.parentPath = appendPath(path)
+	})
+
+	return data, nil
+}
+
+// Replace replaces the data stored by the dmap with v. For a dmap returned by Sub, v is also stored at its path in the parent.
+func (d *DMap) Replace(v interface{}) error {
+	return d.replace(v, true)
+}
+
+// replace is Replace, which only reports the change to the OnChange callbacks if report is true.
+func (d *DMap) replace(v interface{}, report bool) error {
+	if d.isReadOnly() {
+		return ErrReadOnly
+	}
+
+	old := d.data
+
+	parent := d.parent()
+	if parent != nil {
+		// The parent reports the change, with the path of the dmap in it.
+		err := parent.store(v, d.options.parentPath, report)
+		if err != nil {
+			return err
+		}
+	}
+
+	d.data = v
+
+	if parent == nil && report {
+		d.notify(nil, old, v)
+	}
+
+	return nil
+}
+
+// SetData makes the dmap store v instead of its data, so that everyone holding the dmap sees v. The access to the dmap has to be synchronized by the caller.
+// The data it stored is left untouched, along with any parent from Sub. It does nothing on a frozen dmap, see TrySetData.
+func (d *DMap) SetData(v interface{}) {
+	d.TrySetData(v)
+}
+
+// TrySetData is like SetData, but returns ErrReadOnly on a frozen dmap.
+func (d *DMap) TrySetData(v interface{}) error {
+	if d.isReadOnly() {
+		return ErrReadOnly
+	}
+	d.data = v
+	return nil
+}
+
+// Reset removes the data from the dmap, like SetData(nil).
+func (d *DMap) Reset() {
+	d.SetData(nil)
+}
+
+// Rebase makes the dmap store the data at a given path instead of its data, like SetData. The path has to exist, and a frozen dmap returns ErrReadOnly.
+func (d *DMap) Rebase(path ...interface{}) error {
+	if d.isReadOnly() {
+		return ErrReadOnly
+	}
+
+	data, err := d.Get(path...)
+	if err != nil {
+		return err
+	}
+
+	if d.parent() != nil {
+		d.setOptions(func(o *dmapOptions) { o.parentPath = appendPath(o.parentPath, path...) })
+	}
+	d.data = data.Data()
+
+	return nil
+}
+
+// Set sets value at a given path, creating the maps which are missing along it. Each part of the path is used according to the data it is applied to: a key for a map and an index for a slice. Missing keys and nil values are replaced by a new map[string]interface{}, while indices have to exist already. An empty path replaces the whole data.
+func (d *DMap) Set(value interface{}, path ...interface{}) error {
+	return d.setPath(value, path)
+}
+
+// SetSlicePath is like Set, but takes the path as a slice, which is passed along without being copied.
+func (d *DMap) SetSlicePath(value interface{}, path []interface{}) error {
+	return d.setPath(value, path)
+}
+
+// SetIfAbsent sets value at a given path like Set, but only if there is no data at the path yet, and reports whether it was set. An error is only returned if the value cannot be set, like when a scalar is in the way.
+func (d *DMap) SetIfAbsent(value interface{}, path ...interface{}) (bool, error) {
+	if d.Exists(path...) {
+		return false, nil
+	}
+
+	err := d.Set(value, path...)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Set sets value at a given path of the dmap like the Set method does. The type parameter documents the type of value at the call site.
+func Set[T any](d *DMap, value T, path ...interface{}) error {
+	return d.Set(value, path...)
+}
+
+// GetOrCompute returns the data at a given path if it exists. Otherwise it calls compute and stores the returned value at the path, creating the maps which are missing along it like Set, so the dmap is modified. If compute returns an error, it is returned and nothing is stored.
+func (d *DMap) GetOrCompute(compute func() (interface{}, error), path ...interface{}) (*DMap, error) {
+	if data, ok := d.Lookup(path...); ok {
+		return data, nil
+	}
+
+	v, err := compute()
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.setPath(v, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.wrap(v), nil
+}
+
+// Transaction calls fn with a deep copy of the dmap, and replaces the data of the dmap with the data of the copy if fn returns nil, like Replace does. If fn returns an error, the dmap is left untouched and the error is returned.
+// Copying the data costs time and memory proportional to its size, every time Transaction is called.
+func (d *DMap) Transaction(fn func(tx *DMap) error) error {
+	if d.isReadOnly() {
+		return ErrReadOnly
+	}
+
+	tx := d.wrap(deepCopy(d.Data()))
+	// The changes are reported once they are applied to the dmap.
+	tx.setOptions(func(o *dmapOptions) { o.watchers = nil })
+
+	err := fn(tx)
+	if err != nil {
+		return err
+	}
+
+	return d.Replace(tx.Data())
+}
+
+// Clone returns a new dmap with a deep copy of the data, which can be modified without affecting the dmap.
+func (d *DMap) Clone() *DMap {
+	return &DMap{data: deepCopy(d.Data())}
+}
+
+// CloneTo returns a new dmap with a copy of the data in which the maps and slices are copied down to depth levels, with the root at level 1, and the data below them is shared with the dmap. A depth of 0 shares the whole data, and a negative depth copies it all like Clone.
+// Keys and elements can be set, added or removed at the copied levels without affecting the dmap, but the shared maps and slices are the same ones in both dmaps, so changes made inside them are visible in both.
+func (d *DMap) CloneTo(depth int) *DMap {
+	return &DMap{data: copyDepth(d.Data(), depth)}
+}
+
+// setAt stores v at an existing path, either as a key of a map or an index of a slice. An empty path replaces the whole data.
+func (d *DMap) setAt(v interface{}, path []interface{}) error {
+	return d.store(v, path, true)
+}
+
+// store is setAt, which only reports the change to the OnChange callbacks if report is true.
+func (d *DMap) store(v interface{}, path []interface{}, report bool) error {
+	if len(path) == 0 {
+		return d.replace(v, report)
+	}
+
+	if d.isReadOnly() {
+		return ErrReadOnly
+	}
+
+	last := len(path) - 1
+	parent, err := d.Get(path[:last]...)
+	if err != nil {
+		return err
+	}
+
+	if isMap(parent.Data()) {
+		old, _ := mapValue(parent.Data(), path[last])
+		err := setMapValue(parent.Data(), path[last], v, path)
+		if err != nil {
+			return err
+		}
+
+		if report {
+			d.notify(path, old, v)
+		}
+		return nil
+	}
+
+	if data, ok := parent.Data().([]interface{}); ok {
+		index, ok := path[last].(int)
+		if !ok {
+			return fmt.Errorf(errorExpectedIndex, path[last], path[last], path)
+		}
+
+		if index < 0 || index >= len(data) {
+			return fmt.Errorf(errorIndexOutOfRange, index, path)
+		}
+
+		old := data[index]
+		data[index] = v
+		if report {
+			d.notify(path, old, v)
+		}
+		return nil
+	}
+
+	return fmt.Errorf(errorUnexpectedType, path[:last])
+}
+
+// deleteAt removes the data at an existing path, either a key of a map or an element of a slice. The slice is replaced with a new one without the element, and the removal is reported at the path of the element.
+func (d *DMap) deleteAt(path []interface{}) error {
+	if d.isReadOnly() {
+		return ErrReadOnly
+	}
+
+	last := len(path) - 1
+	parent, err := d.Get(path[:last]...)
+	if err != nil {
+		return err
+	}
+
+	if isMap(parent.Data()) {
+		old, ok := mapValue(parent.Data(), path[last])
+		if !ok {
+			return fmt.Errorf(errorKeyNotFound, path[last], path)
+		}
+
+		deleteMapValue(parent.Data(), path[last])
+		d.notify(path, old, nil)
+		return nil
+	}
+
+	if data, ok := parent.Data().([]interface{}); ok {
+		index, ok := path[last].(int)
+		if !ok {
+			return fmt.Errorf(errorExpectedIndex, path[last], path[last], path)
+		}
+
+		if index < 0 || index >= len(data) {
+			return fmt.Errorf(errorIndexOutOfRange, index, path)
+		}
+
+		s := make([]interface{}, 0, len(data)-1)
+		s = append(s, data[:index]...)
+		err := d.store(append(s, data[index+1:]...), path[:last], false)
+		if err != nil {
+			return err
+		}
+
+		d.notify(path, data[index], nil)
+		return nil
+	}
+
+	return fmt.Errorf(errorUnexpectedType, path[:last])
+}
+
+// setPath stores v at a path, creating the maps which are missing along it. Missing keys and nil values are replaced by a new map[string]interface{}, while indices have to exist already. An empty path replaces the whole data.
+func (d *DMap) setPath(v interface{}, path []interface{}) error {
+	if d.isReadOnly() {
+		return ErrReadOnly
+	}
+
+	if len(path) == 0 {
+		return d.Replace(v)
+	}
+
+	if !d.HasData() {
+		err := d.Replace(map[string]interface{}{})
+		if err != nil {
+			return err
+		}
+	}
+
+	currentData := d.Data()
+	last := len(path) - 1
+
+	for i, p := range path {
+		var next interface{}
+
+		if isMap(currentData) {
+			if i == last {
+				old, _ := mapValue(currentData, p)
+				err := setMapValue(currentData, p, v, path)
+				if err != nil {
+					return err
+				}
+
+				d.notify(path, old, v)
+				return nil
+			}
+
+			next, _ = mapValue(currentData, p)
+			if next == nil {
+				next = map[string]interface{}{}
+				err := setMapValue(currentData, p, next, path[:i+1])
+				if err != nil {
+					return err
+				}
+			}
+
+		} else if data, ok := currentData.([]interface{}); ok {
+			index, ok := p.(int)
+			if !ok {
+				return fmt.Errorf(errorExpectedIndex, p, p, path[:i+1])
+			}
+
+			if index < 0 || index >= len(data) {
+				return fmt.Errorf(errorIndexOutOfRange, index, path[:i+1])
+			}
+
+			if i == last {
+				old := data[index]
+				data[index] = v
+				d.notify(path, old, v)
+				return nil
+			}
+
+			next = data[index]
+			if next == nil {
+				next = map[string]interface{}{}
+				data[index] = next
+			}
+
+		} else {
+			return fmt.Errorf(errorUnexpectedType, path[:i])
+		}
+
+		currentData = next
+	}
+
+	return nil
+}
+
+// wrap returns a new dmap for v which is a part of the data of d, carrying over the flags of d.
+func (d *DMap) wrap(v interface{}) *DMap {
+	if d == nil {
+		return &DMap{data: v}
+	}
+
+	options := d.options
+	if options != nil && options.parent != nil {
+		inherited := *options
+		inherited.parent, inherited.parentPath = nil, nil
+		options = &inherited
+	}
+
+	return &DMap{data: v, options: options}
+}
+
+// setOptions replaces the options of the dmap with a copy modified by fn, leaving the options shared with other dmaps untouched.
+func (d *DMap) setOptions(fn func(o *dmapOptions)) {
+	var options dmapOptions
+	if d.options != nil {
+		options = *d.options
+	}
+	fn(&options)
+	d.options = &options
+}
+
+// isReadOnly checks if the dmap is frozen.
+func (d *DMap) isReadOnly() bool {
+	return d != nil && d.options != nil && d.options.readOnly
+}
+
+// parent returns the dmap which the dmap was returned by if it was returned by Sub, and nil otherwise.
+func (d *DMap) parent() *DMap {
+	if d.options == nil {
+		return nil
+	}
+	return d.options.parent
+}
+
+// notify calls the callbacks registered with OnChange, if there are any, for a change at path.
+func (d *DMap) notify(path []interface{}, oldVal, newVal interface{}) {
+	if d == nil || d.options == nil {
+		return
+	}
+
+	for _, fn := range d.options.watchers {
+		fn(path, oldVal, newVal)
+	}
+}
+
+// failAt returns a getError for args followed by the path up to its part i, after calling the error hook with it. The path is copied, so that the path passed to Get does not escape to the heap when Get succeeds.
+func (d *DMap) failAt(path []interface{}, i int, format string, args ...interface{}) error {
+	copied := append([]interface{}(nil), path...)
+	return d.fail(copied, &getError{format: format, args: append(args, copied[:i+1])})
+}
+
+// fail calls the error hook of the dmap, if there is one, and returns err.
+func (d *DMap) fail(path []interface{}, err error) error {
+	if d != nil && d.options != nil && d.options.errorHook != nil {
+		d.options.errorHook(path, err)
+	}
+	return err
+}
+
+// asMapSI returns v as map[string]interface{}, converting a map[interface{}]interface{} with string keys or an *OrderedMap to a new map. The path of v is used for errors.
+func asMapSI(v interface{}, path []interface{}) (map[string]interface{}, error) {
+	switch m := v.(type) {
+	case map[string]interface{}:
+		return m, nil
+
+	case map[interface{}]interface{}:
+		mapSI := make(map[string]interface{}, len(m))
+		for k, v := range m {
+			key, ok := k.(string)
+			if !ok {
+				return nil, fmt.Errorf(errorNonStringKey, k, k, path)
+			}
+			mapSI[key] = v
+		}
+		return mapSI, nil
+
+	case *OrderedMap:
+		return m.toMapSI(), nil
+	}
+
+	return nil, fmt.Errorf(errorNotMapSI, path)
+}
+
+// depth returns the nesting depth of v: 0 for a scalar, 1 for a map or slice containing only scalars, and so on.
+func depth(v interface{}) int {
+	max := 0
+
+	switch v := v.(type) {
+	case map[string]interface{}, map[interface{}]interface{}, *OrderedMap:
+		eachMapEntry(v, func(_, value interface{}) bool {
+			if d := depth(value); d > max {
+				max = d
+			}
+			return true
+		})
+
+	case []interface{}:
+		for _, elem := range v {
+			if d := depth(elem); d > max {
+				max = d
+			}
+		}
+
+	default:
+		return 0
+	}
+
+	return max + 1
+}
+
+// isMap checks if v is a map[string]interface{}, a map[interface{}]interface{} or an *OrderedMap.
+func isMap(v interface{}) bool {
+	switch v.(type) {
+	case map[string]interface{}, map[interface{}]interface{}, *OrderedMap:
+		return true
+	}
+	return false
+}
+
+// mapValue returns the value stored under key in the map m, which has to be one of the types accepted by isMap.
+func mapValue(m interface{}, key interface{}) (interface{}, bool) {
+	switch m := m.(type) {
+	case map[string]interface{}:
+		k, ok := key.(string)
+		if !ok {
+			return nil, false
+		}
+		v, ok := m[k]
+		return v, ok
+
+	case map[interface{}]interface{}:
+		v, ok := m[key]
+		return v, ok
+
+	case *OrderedMap:
+		k, ok := key.(string)
+		if !ok {
+			return nil, false
+		}
+		return m.Value(k)
+	}
+	return nil, false
+}
+
+// setMapValue stores v under key in the map m, which has to be one of the types accepted by isMap. The path of the key is used for errors.
+func setMapValue(m interface{}, key, v interface{}, path []interface{}) error {
+	switch m := m.(type) {
+	case map[string]interface{}:
+		k, ok := key.(string)
+		if !ok {
+			return fmt.Errorf(errorExpectedKey, key, key, path)
+		}
+		m[k] = v
+
+	case map[interface{}]interface{}:
+		m[key] = v
+
+	case *OrderedMap:
+		k, ok := key.(string)
+		if !ok {
+			return fmt.Errorf(errorExpectedKey, key, key, path)
+		}
+		m.Set(k, v)
+
+	default:
+		return fmt.Errorf(errorUnexpectedType, path[:len(path)-1])
+	}
+
+	return nil
+}
+
+// deleteMapValue removes key from the map m, which has to be one of the types accepted by isMap.
+func deleteMapValue(m interface{}, key interface{}) {
+	switch m := m.(type) {
+	case map[string]interface{}:
+		if k, ok := key.(string); ok {
+			delete(m, k)
+		}
+
+	case map[interface{}]interface{}:
+		delete(m, key)
+
+	case *OrderedMap:
+		if k, ok := key.(string); ok {
+			m.Delete(k)
+		}
+	}
+}
+
+// deepCopy returns a copy of v in which every map and slice is copied as well.
+func deepCopy(v interface{}) interface{} {
+	return copyDepth(v, -1)
+}
+
+// copyDepth returns a copy of v in which the maps and slices are copied down to depth levels, sharing the data below them. A negative depth copies every level.
+func copyDepth(v interface{}, depth int) interface{} {
+	if depth == 0 {
+		return v
+	}
+	depth--
+
+	switch v := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, elem := range v {
+			m[k] = copyDepth(elem, depth)
+		}
+		return m
+
+	case map[interface{}]interface{}:
+		m := make(map[interface{}]interface{}, len(v))
+		for k, elem := range v {
+			m[k] = copyDepth(elem, depth)
+		}
+		return m
+
+	case *OrderedMap:
+		m := NewOrderedMap()
+		for _, k := range v.keys {
+			m.Set(k, copyDepth(v.values[k], depth))
+		}
+		return m
+
+	case []interface{}:
+		s := make([]interface{}, len(v))
+		for i, elem := range v {
+			s[i] = copyDepth(elem, depth)
+		}
+		return s
+	}
+
+	return v
+}
+
+// eachMapEntry calls fn for every entry of the map m until fn returns false. The entries of an *OrderedMap are visited in order.
+func eachMapEntry(m interface{}, fn func(key, value interface{}) bool) {
+	switch m := m.(type) {
+	case map[string]interface{}:
+		for k, v := range m {
+			if !fn(k, v) {
+				return
+			}
+		}
+
+	case map[interface{}]interface{}:
+		for k, v := range m {
+			if !fn(k, v) {
+				return
+			}
+		}
+
+	case *OrderedMap:
+		for _, k := range m.Keys() {
+			if !fn(k, m.values[k]) {
+				return
+			}
+		}
+	}
+}
+
+// appendPath returns a new path with p added at the end, leaving path untouched.
+func appendPath(path []interface{}, p ...interface{}) []interface{} {
+	newPath := make([]interface{}, 0, len(path)+len(p))
+	newPath = append(newPath, path...)
+	return append(newPath, p...)
+}
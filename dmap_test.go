@@ -0,0 +1,407 @@
+package dmap
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestSet(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		set     func(d *DMap) error
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "scalar",
+			in:   `{"a":{"b":1}}`,
+			set:  func(d *DMap) error { return Set[int](d, 2, "a", "b") },
+			want: `{"a":{"b":2}}`,
+		},
+		{
+			name: "map",
+			in:   `{"a":{}}`,
+			set: func(d *DMap) error {
+				return Set[map[string]interface{}](d, map[string]interface{}{"c": "x"}, "a", "b")
+			},
+			want: `{"a":{"b":{"c":"x"}}}`,
+		},
+		{
+			name: "missing maps",
+			in:   `{}`,
+			set:  func(d *DMap) error { return Set[int](d, 1, "a", "b", "c") },
+			want: `{"a":{"b":{"c":1}}}`,
+		},
+		{
+			name: "nil replaced by a map",
+			in:   `{"a":null}`,
+			set:  func(d *DMap) error { return Set[string](d, "x", "a", "b") },
+			want: `{"a":{"b":"x"}}`,
+		},
+		{
+			name: "existing index",
+			in:   `{"a":[1,2]}`,
+			set:  func(d *DMap) error { return Set[int](d, 3, "a", 1) },
+			want: `{"a":[1,3]}`,
+		},
+		{
+			name:    "missing index",
+			in:      `{"a":[1,2]}`,
+			set:     func(d *DMap) error { return Set[int](d, 3, "a", 2) },
+			want:    `{"a":[1,2]}`,
+			wantErr: true,
+		},
+		{
+			name:    "scalar in the way",
+			in:      `{"a":1}`,
+			set:     func(d *DMap) error { return Set[int](d, 2, "a", "b") },
+			want:    `{"a":1}`,
+			wantErr: true,
+		},
+		{
+			name: "root",
+			in:   `{"a":1}`,
+			set:  func(d *DMap) error { return Set[[]interface{}](d, []interface{}{"x"}) },
+			want: `["x"]`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := MustParseJSONString(test.in)
+
+			err := test.set(d)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got error %v, want error %v", err, test.wantErr)
+			}
+
+			want := MustParseJSONString(test.want).Data()
+			if !reflect.DeepEqual(normalizeInts(d.Data()), want) {
+				t.Errorf("got %v, want %v", d.Data(), want)
+			}
+		})
+	}
+}
+
+func TestSetReadOnly(t *testing.T) {
+	d := MustParseJSONString(`{"a":1}`)
+	frozen := d.Freeze()
+
+	err := Set[int](frozen, 2, "a")
+	if !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("got error %v, want ErrReadOnly", err)
+	}
+
+	err = Set[map[string]interface{}](frozen, map[string]interface{}{}, "b")
+	if !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("got error %v, want ErrReadOnly", err)
+	}
+
+	err = frozen.TrySetData("x")
+	if !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("got error %v, want ErrReadOnly", err)
+	}
+
+	if !reflect.DeepEqual(d.Data(), map[string]interface{}{"a": float64(1)}) {
+		t.Errorf("frozen view was modified: %v", d.Data())
+	}
+	if !reflect.DeepEqual(frozen.Data(), d.Data()) {
+		t.Errorf("frozen view was repointed: %v", frozen.Data())
+	}
+}
+
+func TestGetSliceIOrEmpty(t *testing.T) {
+	d := MustParseJSONString(`{"a":{"list":[1],"null":null,"scalar":1},"s":[]}`)
+
+	tests := []struct {
+		name    string
+		path    []interface{}
+		want    []interface{}
+		wantErr bool
+	}{
+		{name: "slice", path: []interface{}{"a", "list"}, want: []interface{}{float64(1)}},
+		{name: "missing key", path: []interface{}{"a", "other"}, want: []interface{}{}},
+		{name: "missing intermediate key", path: []interface{}{"b", "list"}, want: []interface{}{}},
+		{name: "missing index", path: []interface{}{"s", 0}, want: []interface{}{}},
+		{name: "null", path: []interface{}{"a", "null"}, wantErr: true},
+		{name: "null intermediate", path: []interface{}{"a", "null", "list"}, wantErr: true},
+		{name: "scalar intermediate", path: []interface{}{"a", "scalar", "list"}, wantErr: true},
+		{name: "wrong type", path: []interface{}{"a", "scalar"}, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := d.GetSliceIOrEmpty(test.path...)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got error %v, want error %v", err, test.wantErr)
+			}
+			if !test.wantErr && (got == nil || !reflect.DeepEqual(got, test.want)) {
+				t.Errorf("got %#v, want %#v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGetIntoDisallowUnknownFields(t *testing.T) {
+	in := `{"server":{"host":"example","prot":80}}`
+	var out struct{ Host string }
+
+	err := MustParseJSONString(in).GetInto(&out, "server")
+	if err != nil {
+		t.Fatalf("default parser: got error %v", err)
+	}
+
+	d, err := (&Parser{DisallowUnknownFields: true}).ParseString(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server, err := d.Get("server")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = server.GetInto(&out)
+	if err == nil {
+		t.Error("parser with DisallowUnknownFields: got no error")
+	}
+}
+
+func TestSubReplace(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		freeze  bool
+		path    []interface{}
+		change  func(sub *DMap) error
+		want    string
+		wantErr error
+	}{
+		{
+			name:   "scalar",
+			in:     `{"a":{"b":1}}`,
+			path:   []interface{}{"a", "b"},
+			change: func(sub *DMap) error { return sub.Replace("x") },
+			want:   `{"a":{"b":"x"}}`,
+		},
+		{
+			name:   "map",
+			in:     `{"a":{"b":1}}`,
+			path:   []interface{}{"a"},
+			change: func(sub *DMap) error { return sub.Replace(map[string]interface{}{"c": "x"}) },
+			want:   `{"a":{"c":"x"}}`,
+		},
+		{
+			name:   "element",
+			in:     `{"a":[1,2]}`,
+			path:   []interface{}{"a", 1},
+			change: func(sub *DMap) error { return sub.Replace("x") },
+			want:   `{"a":[1,"x"]}`,
+		},
+		{
+			name:   "set inside",
+			in:     `{"a":{"b":1}}`,
+			path:   []interface{}{"a"},
+			change: func(sub *DMap) error { return sub.Set("x", "c") },
+			want:   `{"a":{"b":1,"c":"x"}}`,
+		},
+		{
+			name: "nested sub",
+			in:   `{"a":{"b":{"c":1}}}`,
+			path: []interface{}{"a"},
+			change: func(sub *DMap) error {
+				nested, err := sub.Sub("b", "c")
+				if err != nil {
+					return err
+				}
+				return nested.Replace("x")
+			},
+			want: `{"a":{"b":{"c":"x"}}}`,
+		},
+		{
+			name: "rebased sub",
+			in:   `{"a":{"b":{"c":1}}}`,
+			path: []interface{}{"a"},
+			change: func(sub *DMap) error {
+				err := sub.Rebase("b")
+				if err != nil {
+					return err
+				}
+				return sub.Replace("x")
+			},
+			want: `{"a":{"b":"x"}}`,
+		},
+		{
+			name:    "frozen parent",
+			in:      `{"a":{"b":1}}`,
+			freeze:  true,
+			path:    []interface{}{"a", "b"},
+			change:  func(sub *DMap) error { return sub.Replace("x") },
+			want:    `{"a":{"b":1}}`,
+			wantErr: ErrReadOnly,
+		},
+		{
+			name:    "frozen parent set inside",
+			in:      `{"a":{"b":1}}`,
+			freeze:  true,
+			path:    []interface{}{"a"},
+			change:  func(sub *DMap) error { return sub.Set("x", "b") },
+			want:    `{"a":{"b":1}}`,
+			wantErr: ErrReadOnly,
+		},
+		{
+			name:    "frozen sub",
+			in:      `{"a":{"b":1}}`,
+			path:    []interface{}{"a", "b"},
+			change:  func(sub *DMap) error { return sub.Freeze().Replace("x") },
+			want:    `{"a":{"b":1}}`,
+			wantErr: ErrReadOnly,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := MustParseJSONString(test.in)
+			parent := d
+			if test.freeze {
+				parent = d.Freeze()
+			}
+
+			sub, err := parent.Sub(test.path...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = test.change(sub)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("got error %v, want %v", err, test.wantErr)
+			}
+
+			want := MustParseJSONString(test.want).Data()
+			if !reflect.DeepEqual(d.Data(), want) {
+				t.Errorf("got %v, want %v", d.Data(), want)
+			}
+		})
+	}
+}
+
+func TestErrorHookProbing(t *testing.T) {
+	calls := 0
+	d := MustParseJSONString(`{"a":{"b":1}}`).WithErrorHook(func(path []interface{}, err error) {
+		calls++
+	})
+
+	if _, ok := d.Lookup("a", "c"); ok {
+		t.Error("Lookup found a missing path")
+	}
+	if d.Exists("a", "c") || d.AllExist([]interface{}{"a"}, []interface{}{"c"}) {
+		t.Error("Exists found a missing path")
+	}
+	if calls != 0 {
+		t.Errorf("probing called the hook %v times", calls)
+	}
+
+	data, ok := d.Lookup("a")
+	if !ok {
+		t.Fatal("Lookup did not find a")
+	}
+	data.Get("c")
+	if calls != 1 {
+		t.Errorf("the result of Lookup called the hook %v times, want 1", calls)
+	}
+}
+
+// normalizeInts returns a copy of v with the ints converted to float64, like they are read back from JSON.
+func normalizeInts(v interface{}) interface{} {
+	switch v := v.(type) {
+	case int:
+		return float64(v)
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, elem := range v {
+			m[k] = normalizeInts(elem)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(v))
+		for i, elem := range v {
+			s[i] = normalizeInts(elem)
+		}
+		return s
+	}
+	return v
+}
+
+var benchmarkJSON = `{"root":{"contents":{"items":{"first":{"name":"example","tags":["a","b"]}}}}}`
+
+func BenchmarkGet(b *testing.B) {
+	d := MustParseJSONString(benchmarkJSON)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, err := d.Get("root", "contents", "items", "first", "name")
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetFast(b *testing.B) {
+	d := MustParseJSONString(benchmarkJSON)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, err := d.GetFast("root", "contents", "items", "first", "name")
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetMissing(b *testing.B) {
+	d := MustParseJSONString(benchmarkJSON)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, err := d.Get("root", "contents", "items", "second", "name")
+		if err == nil {
+			b.Fatal("expected an error")
+		}
+	}
+}
+
+func BenchmarkGetFastMissing(b *testing.B) {
+	d := MustParseJSONString(benchmarkJSON)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, err := d.GetFast("root", "contents", "items", "second", "name")
+		if err == nil {
+			b.Fatal("expected an error")
+		}
+	}
+}
+
+func BenchmarkCount(b *testing.B) {
+	users := make(map[string]interface{}, 1000)
+	for i := 0; i < 1000; i++ {
+		users[fmt.Sprint(i)] = map[string]interface{}{"email": "user@example.com"}
+	}
+	d := Init(map[string]interface{}{"users": users})
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		n, err := d.Count("users.*.email")
+		if err != nil || n != 1000 {
+			b.Fatal(n, err)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package dmap
+
+import (
+	"math"
+	"reflect"
+)
+
+// EqualWithin compares the data of the dmap with the data of other, treating two numbers as equal if they differ by at most epsilon. All numbers are converted to float64 before being compared, so an integer and a float with the same value are equal.
+// NaN is not equal to any number, including NaN, and an infinity is only equal to the same infinity. Everything else is compared structurally, with maps compared regardless of their key types.
+func (d *DMap) EqualWithin(other *DMap, epsilon float64) bool {
+	return equalNumbers(d.Data(), other.Data(), func(x, y float64) bool {
+		return x == y || math.Abs(x-y) <= epsilon
+	})
+}
+
+// EqualIgnoring compares the data of the dmap with the data of other like the other comparisons of the package, leaving out the values at the ignored paths, like volatile timestamps or identifiers. Both sides are copied before the ignored values are removed, so the dmaps are not modified.
+// An ignored key is removed from the maps on both sides, so it may also be missing on either side. An ignored slice element is replaced with nil on both sides, so the slices still need to have the same length. Placeholder matches every element of a slice, so []interface{}{"items", Placeholder, "id"} ignores the id of every item. An empty path ignores the whole data.
+func (d *DMap) EqualIgnoring(other *DMap, ignore ...[]interface{}) bool {
+	a, b := deepCopy(d.Data()), deepCopy(other.Data())
+
+	for _, path := range ignore {
+		if len(path) == 0 {
+			return true
+		}
+		ignorePath(a, path)
+		ignorePath(b, path)
+	}
+
+	return equal(a, b)
+}
+
+// ignorePath removes the value at path from v, which is modified in place, as described in EqualIgnoring.
+func ignorePath(v interface{}, path []interface{}) {
+	p, rest := path[0], path[1:]
+
+	if isMap(v) {
+		if len(rest) == 0 {
+			deleteMapValue(v, p)
+			return
+		}
+
+		if child, ok := mapValue(v, p); ok {
+			ignorePath(child, rest)
+		}
+		return
+	}
+
+	data, ok := v.([]interface{})
+	if !ok {
+		return
+	}
+
+	for i := range data {
+		if index, ok := p.(int); p != Placeholder && (!ok || index != i) {
+			continue
+		}
+
+		if len(rest) == 0 {
+			data[i] = nil
+		} else {
+			ignorePath(data[i], rest)
+		}
+	}
+}
+
+// equal compares a and b structurally. Numbers are equal if they have the same value as float64 regardless of their types, and maps are equal if they have the same entries regardless of their key types.
+func equal(a, b interface{}) bool {
+	return equalNumbers(a, b, func(x, y float64) bool {
+		return x == y
+	})
+}
+
+// equalNumbers is like equal, but compares the numbers with numbersEqual.
+func equalNumbers(a, b interface{}, numbersEqual func(x, y float64) bool) bool {
+	if x, ok := toFloat64(a); ok {
+		y, ok := toFloat64(b)
+		return ok && numbersEqual(x, y)
+	}
+
+	if isMap(a) {
+		if !isMap(b) || mapLen(a) != mapLen(b) {
+			return false
+		}
+
+		same := true
+		eachMapEntry(a, func(key, x interface{}) bool {
+			y, ok := mapValue(b, key)
+			same = ok && equalNumbers(x, y, numbersEqual)
+			return same
+		})
+		return same
+	}
+
+	if x, ok := a.([]interface{}); ok {
+		y, ok := b.([]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+
+		for i := range x {
+			if !equalNumbers(x[i], y[i], numbersEqual) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// mapLen returns the number of entries in the map m, which has to be one of the types accepted by isMap.
+func mapLen(m interface{}) int {
+	switch m := m.(type) {
+	case map[string]interface{}:
+		return len(m)
+	case map[interface{}]interface{}:
+		return len(m)
+	case *OrderedMap:
+		return m.Len()
+	}
+	return 0
+}
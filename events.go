@@ -0,0 +1,81 @@
+package dmap
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// ParseJSONEvents reads a JSON value from the reader and calls onValue for every element of a top-level array or every value of a top-level object as soon as it has been decoded. It is ParseJSONEventsDepth with a depth of 1.
+func ParseJSONEvents(r io.Reader, onValue func(path []interface{}, value *DMap) error) error {
+	return ParseJSONEventsDepth(r, 1, onValue)
+}
+
+// ParseJSONEventsDepth reads a JSON value from the reader and calls onValue with the path and the data of every value found at the given depth, as soon as that value has been decoded.
+// A depth of 1 reports the elements of a top-level array or the values of a top-level object, a depth of 2 reports the values nested one level below them, and so on. A depth of 0 or less reports the whole document with an empty path.
+// Scalars and empty objects and arrays found above the given depth are reported as well, since they have no values below them. Only the value being decoded is kept in memory, so the memory used depends on the size of the largest reported value rather than on the size of the document.
+// If onValue returns an error, parsing stops and the error is returned.
+func ParseJSONEventsDepth(r io.Reader, depth int, onValue func(path []interface{}, value *DMap) error) error {
+	decoder := json.NewDecoder(r)
+	return decodeEvents(decoder, nil, depth, onValue)
+}
+
+func decodeEvents(decoder *json.Decoder, path []interface{}, depth int, onValue func(path []interface{}, value *DMap) error) error {
+	if depth <= 0 {
+		var v interface{}
+		err := decoder.Decode(&v)
+		if err != nil {
+			return err
+		}
+
+		return onValue(path, &DMap{data: v})
+	}
+
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	switch token {
+	case json.Delim('{'):
+		if !decoder.More() {
+			err = onValue(path, &DMap{data: map[string]interface{}{}})
+			if err != nil {
+				return err
+			}
+		}
+
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return err
+			}
+
+			err = decodeEvents(decoder, appendPath(path, key), depth-1, onValue)
+			if err != nil {
+				return err
+			}
+		}
+
+	case json.Delim('['):
+		if !decoder.More() {
+			err = onValue(path, &DMap{data: []interface{}{}})
+			if err != nil {
+				return err
+			}
+		}
+
+		for i := 0; decoder.More(); i++ {
+			err = decodeEvents(decoder, appendPath(path, i), depth-1, onValue)
+			if err != nil {
+				return err
+			}
+		}
+
+	default:
+		return onValue(path, &DMap{data: token})
+	}
+
+	// consume the closing delimiter
+	_, err = decoder.Token()
+	return err
+}
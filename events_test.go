@@ -0,0 +1,130 @@
+package dmap
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseJSONEventsDepth(t *testing.T) {
+	type event struct {
+		path []interface{}
+		data interface{}
+	}
+
+	tests := []struct {
+		name    string
+		in      string
+		depth   int
+		want    []event
+		wantErr bool
+	}{
+		{
+			name:  "array",
+			in:    `[1, {"a": 2}, [3]]`,
+			depth: 1,
+			want: []event{
+				{[]interface{}{0}, float64(1)},
+				{[]interface{}{1}, map[string]interface{}{"a": float64(2)}},
+				{[]interface{}{2}, []interface{}{float64(3)}},
+			},
+		},
+		{
+			name:  "object",
+			in:    `{"a": 1, "b": {"c": 2}}`,
+			depth: 1,
+			want: []event{
+				{[]interface{}{"a"}, float64(1)},
+				{[]interface{}{"b"}, map[string]interface{}{"c": float64(2)}},
+			},
+		},
+		{
+			name:  "depth 2",
+			in:    `{"users": [{"id": 1}, {"id": 2}], "count": 2}`,
+			depth: 2,
+			want: []event{
+				{[]interface{}{"users", 0}, map[string]interface{}{"id": float64(1)}},
+				{[]interface{}{"users", 1}, map[string]interface{}{"id": float64(2)}},
+				{[]interface{}{"count"}, float64(2)},
+			},
+		},
+		{
+			name:  "empty containers above the depth",
+			in:    `{"a": {}, "b": [], "c": [{}, []]}`,
+			depth: 3,
+			want: []event{
+				{[]interface{}{"a"}, map[string]interface{}{}},
+				{[]interface{}{"b"}, []interface{}{}},
+				{[]interface{}{"c", 0}, map[string]interface{}{}},
+				{[]interface{}{"c", 1}, []interface{}{}},
+			},
+		},
+		{
+			name:  "empty root",
+			in:    `[]`,
+			depth: 1,
+			want: []event{
+				{nil, []interface{}{}},
+			},
+		},
+		{
+			name:  "scalar root",
+			in:    `"x"`,
+			depth: 1,
+			want: []event{
+				{nil, "x"},
+			},
+		},
+		{
+			name:  "whole document",
+			in:    `{"a": [1]}`,
+			depth: 0,
+			want: []event{
+				{nil, map[string]interface{}{"a": []interface{}{float64(1)}}},
+			},
+		},
+		{
+			name:  "invalid after a value",
+			in:    `[1, }`,
+			depth: 1,
+			want: []event{
+				{[]interface{}{0}, float64(1)},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var got []event
+			err := ParseJSONEventsDepth(strings.NewReader(test.in), test.depth, func(path []interface{}, value *DMap) error {
+				got = append(got, event{path, value.Data()})
+				return nil
+			})
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got error %v, want error %v", err, test.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseJSONEventsStop(t *testing.T) {
+	stop := errors.New("stop")
+
+	calls := 0
+	err := ParseJSONEvents(strings.NewReader(`[1, 2, 3]`), func(path []interface{}, value *DMap) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) {
+		t.Fatalf("got error %v, want %v", err, stop)
+	}
+	if calls != 1 {
+		t.Errorf("got %v calls, want 1", calls)
+	}
+}
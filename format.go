@@ -0,0 +1,131 @@
+package dmap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+var (
+	errorUnknownFormat     = "cannot detect the format of the data"
+	errorUnsupportedFormat = "cannot parse %v data, there is no codec for it"
+)
+
+// byteOrderMark is the UTF-8 encoding of U+FEFF, which some editors write at the start of text files.
+var byteOrderMark = []byte("\xef\xbb\xbf")
+
+// Format is a serialization format detected by Sniff.
+type Format int
+
+const (
+	// FormatUnknown is returned along with an error when the format is not detected.
+	FormatUnknown Format = iota
+	// FormatJSON is JSON.
+	FormatJSON
+	// FormatYAML is YAML.
+	FormatYAML
+	// FormatTOML is TOML.
+	FormatTOML
+	// FormatXML is XML.
+	FormatXML
+)
+
+// String returns the name of the format.
+func (f Format) String() string {
+	switch f {
+	case FormatJSON:
+		return "JSON"
+	case FormatYAML:
+		return "YAML"
+	case FormatTOML:
+		return "TOML"
+	case FormatXML:
+		return "XML"
+	}
+	return "unknown"
+}
+
+// Sniff guesses the format of the bytes from their start, ignoring a byte order mark and the surrounding white space. Valid JSON is always JSON.
+// A [table] header or a key = value line is TOML, while ---, a "- " item, a key: value line or another flow collection is YAML, and < is XML.
+func Sniff(b []byte) (Format, error) {
+	b = bytes.TrimPrefix(b, byteOrderMark)
+	content := strings.TrimSpace(string(b))
+	if content == "" {
+		return FormatUnknown, fmt.Errorf(errorUnknownFormat)
+	}
+
+	if content[0] == '<' {
+		return FormatXML, nil
+	}
+
+	if json.Valid([]byte(content)) {
+		return FormatJSON, nil
+	}
+
+	if strings.HasPrefix(content, "---") || strings.HasPrefix(content, "- ") || content == "-" {
+		return FormatYAML, nil
+	}
+
+	var line string
+	for _, l := range strings.Split(content, "\n") {
+		l = strings.TrimSpace(l)
+		if l != "" && !strings.HasPrefix(l, "#") {
+			line = l
+			break
+		}
+	}
+
+	if isTOMLHeader(line) && strings.Contains(content, "\n") {
+		return FormatTOML, nil
+	}
+
+	if content[0] == '[' || content[0] == '{' {
+		return FormatYAML, nil
+	}
+
+	eq, colon := strings.Index(line, "="), strings.Index(line, ":")
+	if eq > 0 && (colon < 0 || colon > eq) {
+		return FormatTOML, nil
+	}
+
+	if colon > 0 && (colon == len(line)-1 || line[colon+1] == ' ' || line[colon+1] == '\t') {
+		return FormatYAML, nil
+	}
+
+	return FormatUnknown, fmt.Errorf(errorUnknownFormat)
+}
+
+// isTOMLHeader checks if line is a [table] or [[table]] header, whose name is made of keys separated by dots, unlike a YAML flow sequence.
+func isTOMLHeader(line string) bool {
+	name := strings.TrimSuffix(strings.TrimPrefix(line, "["), "]")
+	if len(name) == len(line) || name == "" {
+		return false
+	}
+	if strings.HasPrefix(name, "[") != strings.HasSuffix(name, "]") {
+		return false
+	}
+	name = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(name, "["), "]"))
+
+	return name != "" && strings.IndexFunc(name, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("_-.\"' ", r))
+	}) < 0
+}
+
+// ParseAuto returns a new dmap with the bytes decoded by the codec of the format detected by Sniff. JSON uses JSONCodec unless codecs has another one.
+func ParseAuto(b []byte, codecs map[Format]Codec) (*DMap, error) {
+	format, err := Sniff(b)
+	if err != nil {
+		return nil, err
+	}
+
+	codec, ok := codecs[format]
+	if !ok && format == FormatJSON {
+		codec, ok = JSONCodec, true
+	}
+	if !ok {
+		return nil, fmt.Errorf(errorUnsupportedFormat, format)
+	}
+
+	return Parse(codec, bytes.TrimPrefix(b, byteOrderMark))
+}
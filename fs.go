@@ -0,0 +1,191 @@
+package dmap
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FS returns a read-only fs.FS sharing the data of the dmap, in which maps and slices are directories and other values are files holding JSON.
+func (d *DMap) FS() fs.FS {
+	return dmapFS{data: d.Data()}
+}
+
+type dmapFS struct {
+	data interface{}
+}
+
+func (f dmapFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+
+	v := f.data
+	if name != "." {
+		for _, part := range strings.Split(name, "/") {
+			child, ok := fsChild(v, part)
+			if !ok {
+				return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+			}
+			v = child
+		}
+	}
+
+	info := &fsInfo{name: ".", dir: true}
+	if name != "." {
+		var err error
+		info, err = fsStat(path.Base(name), v)
+		if err != nil {
+			return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+		}
+	}
+
+	if info.IsDir() {
+		entries, err := fsEntries(v)
+		if err != nil {
+			return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+		}
+		return &fsDir{info: info, path: name, entries: entries}, nil
+	}
+
+	return &fsFile{info: info, Reader: bytes.NewReader(info.content)}, nil
+}
+
+// fsChild returns the value of the map or the slice v which has the file name name.
+func fsChild(v interface{}, name string) (interface{}, bool) {
+	if isMap(v) {
+		for _, key := range sortedKeys(v) {
+			if fmt.Sprint(key) == name {
+				return mapValue(v, key)
+			}
+		}
+		return nil, false
+	}
+
+	if data, ok := v.([]interface{}); ok {
+		i, err := strconv.Atoi(name)
+		if err != nil || strconv.Itoa(i) != name || i < 0 || i >= len(data) {
+			return nil, false
+		}
+		return data[i], true
+	}
+
+	return nil, false
+}
+
+// fsEntries returns the entries of the directory for the map or the slice v, sorted by name.
+func fsEntries(v interface{}) ([]fs.DirEntry, error) {
+	var names []string
+	values := map[string]interface{}{}
+
+	if isMap(v) {
+		for _, key := range sortedKeys(v) {
+			name := fmt.Sprint(key)
+			if _, ok := values[name]; ok || name == "." || !fs.ValidPath(name) || strings.Contains(name, "/") {
+				continue
+			}
+
+			names = append(names, name)
+			values[name], _ = mapValue(v, key)
+		}
+	} else if data, ok := v.([]interface{}); ok {
+		for i, elem := range data {
+			name := strconv.Itoa(i)
+			names = append(names, name)
+			values[name] = elem
+		}
+	}
+
+	sort.Strings(names)
+
+	entries := make([]fs.DirEntry, len(names))
+	for i, name := range names {
+		info, err := fsStat(name, values[name])
+		if err != nil {
+			return nil, err
+		}
+		entries[i] = fs.FileInfoToDirEntry(info)
+	}
+
+	return entries, nil
+}
+
+// fsStat returns the file info of the file or directory named name for v, along with the content of a file.
+func fsStat(name string, v interface{}) (*fsInfo, error) {
+	if isMap(v) || isSlice(v) {
+		return &fsInfo{name: name, dir: true}, nil
+	}
+
+	content, err := JSONCodec.Encode(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fsInfo{name: name, content: content}, nil
+}
+
+type fsInfo struct {
+	name    string
+	dir     bool
+	content []byte
+}
+
+func (i *fsInfo) Name() string       { return i.name }
+func (i *fsInfo) Size() int64        { return int64(len(i.content)) }
+func (i *fsInfo) ModTime() time.Time { return time.Time{} }
+func (i *fsInfo) IsDir() bool        { return i.dir }
+func (i *fsInfo) Sys() interface{}   { return nil }
+
+func (i *fsInfo) Mode() fs.FileMode {
+	if i.dir {
+		return fs.ModeDir | 0555
+	}
+	return 0444
+}
+
+type fsFile struct {
+	info *fsInfo
+	*bytes.Reader
+}
+
+func (f *fsFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *fsFile) Close() error               { return nil }
+
+type fsDir struct {
+	info    *fsInfo
+	path    string
+	entries []fs.DirEntry
+	offset  int
+}
+
+func (d *fsDir) Stat() (fs.FileInfo, error) { return d.info, nil }
+func (d *fsDir) Close() error               { return nil }
+
+func (d *fsDir) Read(_ []byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.path, Err: errors.New("is a directory")}
+}
+
+func (d *fsDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	rest := d.entries[d.offset:]
+	if n <= 0 {
+		d.offset = len(d.entries)
+		return rest, nil
+	}
+
+	if len(rest) == 0 {
+		return nil, io.EOF
+	}
+
+	if n > len(rest) {
+		n = len(rest)
+	}
+	d.offset += n
+	return rest[:n], nil
+}
@@ -0,0 +1,80 @@
+package dmap
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+)
+
+func init() {
+	gob.Register(map[string]interface{}{})
+	gob.Register(map[interface{}]interface{}{})
+	gob.Register([]interface{}{})
+	gob.Register(&OrderedMap{})
+	gob.Register(json.Number(""))
+}
+
+// ParseGob returns a new dmap with the gob bytes decoded, as encoded by ToGob. The supported values are map[string]interface{}, map[interface{}]interface{}, *OrderedMap, []interface{}, json.Number, and the bools, strings, numbers and slices of them which gob registers itself. Any other type has to be registered with gob.Register by the caller, on both sides.
+func ParseGob(b []byte) (*DMap, error) {
+	var v interface{}
+	err := gob.NewDecoder(bytes.NewReader(b)).Decode(&v)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DMap{data: v}, nil
+}
+
+// ToGob returns the data of the dmap encoded with gob, which can be decoded by ParseGob. The supported values are listed in ParseGob.
+func (d *DMap) ToGob() ([]byte, error) {
+	v := d.Data()
+
+	var b bytes.Buffer
+	err := gob.NewEncoder(&b).Encode(&v)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// orderedMapGob is how an *OrderedMap is encoded with gob.
+type orderedMapGob struct {
+	Keys   []string
+	Values []interface{}
+}
+
+// GobEncode returns the map encoded with gob, with the keys in order.
+func (m *OrderedMap) GobEncode() ([]byte, error) {
+	o := orderedMapGob{
+		Keys:   m.keys,
+		Values: make([]interface{}, len(m.keys)),
+	}
+	for i, k := range m.keys {
+		o.Values[i] = m.values[k]
+	}
+
+	var b bytes.Buffer
+	err := gob.NewEncoder(&b).Encode(o)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// GobDecode replaces the entries of the map with the ones decoded from b, as encoded by GobEncode.
+func (m *OrderedMap) GobDecode(b []byte) error {
+	var o orderedMapGob
+	err := gob.NewDecoder(bytes.NewReader(b)).Decode(&o)
+	if err != nil {
+		return err
+	}
+
+	*m = *NewOrderedMap()
+	for i, k := range o.Keys {
+		m.Set(k, o.Values[i])
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package dmap
+
+import (
+	"fmt"
+	"reflect"
+)
+
+var (
+	errorUnhashableKey  = "value of type %T at path %v cannot be used as a map key"
+	errorMissingIndex   = "element at %v has no value at %v"
+	errorDuplicateIndex = "elements at %v and %v have the same key %v"
+)
+
+// GroupBy groups the elements of the []interface{} at a given path by the value at keyPath within each of them. The elements in which keyPath does not exist go under the nil group, and the elements keep their order within a group.
+func (d *DMap) GroupBy(keyPath []interface{}, path ...interface{}) (map[interface{}][]*DMap, error) {
+	return d.groupBy(keyPath, path, false)
+}
+
+// GroupBySkipMissing is like GroupBy, but leaves out the elements in which keyPath does not exist.
+func (d *DMap) GroupBySkipMissing(keyPath []interface{}, path ...interface{}) (map[interface{}][]*DMap, error) {
+	return d.groupBy(keyPath, path, true)
+}
+
+func (d *DMap) groupBy(keyPath []interface{}, path []interface{}, skipMissing bool) (map[interface{}][]*DMap, error) {
+	data, err := d.GetSliceI(path...)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := map[interface{}][]*DMap{}
+	for i, elem := range data {
+		var key interface{}
+
+		value, err := (&DMap{data: elem}).Get(keyPath...)
+		if !isMap(elem) || err != nil {
+			if skipMissing {
+				continue
+			}
+		} else {
+			key = value.Data()
+		}
+
+		if !isHashable(key) {
+			return nil, fmt.Errorf(errorUnhashableKey, key, appendPath(appendPath(path, i), keyPath...))
+		}
+
+		groups[key] = append(groups[key], d.wrap(elem))
+	}
+
+	return groups, nil
+}
+
+// isHashable checks if v can be used as a map key without panicking.
+func isHashable(v interface{}) bool {
+	return v == nil || reflect.TypeOf(v).Comparable()
+}
+
+// IndexBy returns the elements of the []interface{} at a given path by the value at field within each of them, for looking elements up by an id. Every element has to be a map holding a value at field, and two elements with the same value return an error. The values are used as they are, so a json.Number and a float64 with the same value are different keys.
+func (d *DMap) IndexBy(field []interface{}, path ...interface{}) (map[interface{}]*DMap, error) {
+	return d.indexBy(field, path, false)
+}
+
+// IndexByKeepLast is like IndexBy, but when several elements have the same value, the last of them is kept instead of returning an error.
+func (d *DMap) IndexByKeepLast(field []interface{}, path ...interface{}) (map[interface{}]*DMap, error) {
+	return d.indexBy(field, path, true)
+}
+
+func (d *DMap) indexBy(field []interface{}, path []interface{}, keepLast bool) (map[interface{}]*DMap, error) {
+	data, err := d.GetSliceI(path...)
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[interface{}]*DMap, len(data))
+	first := make(map[interface{}]int, len(data))
+	for i, elem := range data {
+		elemPath := appendPath(path, i)
+		if !isMap(elem) {
+			return nil, fmt.Errorf(errorNotMap, elemPath)
+		}
+
+		value, err := (&DMap{data: elem}).Get(field...)
+		if err != nil {
+			return nil, fmt.Errorf(errorMissingIndex, elemPath, field)
+		}
+
+		key := value.Data()
+		if !isHashable(key) {
+			return nil, fmt.Errorf(errorUnhashableKey, key, appendPath(elemPath, field...))
+		}
+
+		if j, ok := first[key]; ok && !keepLast {
+			return nil, fmt.Errorf(errorDuplicateIndex, appendPath(path, j), elemPath, key)
+		}
+
+		first[key] = i
+		index[key] = d.wrap(elem)
+	}
+
+	return index, nil
+}
@@ -0,0 +1,45 @@
+//go:build hcl
+
+package dmap
+
+import (
+	"github.com/hashicorp/hcl"
+)
+
+// ParseHCL returns a new dmap with the HCL bytes decoded. It is only built with the hcl build tag, as it needs github.com/hashicorp/hcl.
+// Blocks become maps keyed by their labels, and repeated blocks become slices of maps. Interpolations are kept as strings, and HCL2 is not supported.
+func ParseHCL(b []byte) (*DMap, error) {
+	var v interface{}
+	err := hcl.Unmarshal(b, &v)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DMap{data: normalizeHCL(v)}, nil
+}
+
+// normalizeHCL converts the list of maps decoded for a block to a map, or to a []interface{} of maps if the block is repeated.
+func normalizeHCL(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, elem := range v {
+			v[key] = normalizeHCL(elem)
+		}
+		return v
+	case []map[string]interface{}:
+		if len(v) == 1 {
+			return normalizeHCL(v[0])
+		}
+		s := make([]interface{}, len(v))
+		for i, elem := range v {
+			s[i] = normalizeHCL(elem)
+		}
+		return s
+	case []interface{}:
+		for i, elem := range v {
+			v[i] = normalizeHCL(elem)
+		}
+		return v
+	}
+	return v
+}
@@ -0,0 +1,84 @@
+package dmap
+
+import (
+	"sync"
+)
+
+// StringPool holds one copy of every string added to it, so that equal strings from different documents can share their memory. Its zero value is an empty pool ready to use. It is safe for concurrent use, and a pool can be shared by any number of parses.
+// A pool keeps every string added to it as long as it is referenced, so it grows with the number of distinct strings. Use one pool per stream of documents with a similar schema, and call Reset or drop the pool once the strings are no longer repeated.
+type StringPool struct {
+	mu      sync.Mutex
+	strings map[string]string
+}
+
+// NewStringPool returns a new empty string pool.
+func NewStringPool() *StringPool {
+	return &StringPool{
+		strings: map[string]string{},
+	}
+}
+
+// Intern returns the copy of s held by the pool, adding s to the pool if it was not in it yet.
+func (p *StringPool) Intern(s string) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if interned, ok := p.strings[s]; ok {
+		return interned
+	}
+
+	if p.strings == nil {
+		p.strings = map[string]string{}
+	}
+	p.strings[s] = s
+	return s
+}
+
+// Len returns the number of strings in the pool.
+func (p *StringPool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return len(p.strings)
+}
+
+// Reset removes every string from the pool. The strings returned by the pool before stay valid.
+func (p *StringPool) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.strings = map[string]string{}
+}
+
+// ParseJSONInterned is like ParseJSONBytes, but passes every key and every string value through the pool, so that the strings repeated across the documents parsed with the same pool are kept in memory only once. A nil pool parses like ParseJSONBytes.
+func ParseJSONInterned(jsonBytes []byte, pool *StringPool) (*DMap, error) {
+	d, err := ParseJSONBytes(jsonBytes)
+	if err != nil || pool == nil {
+		return d, err
+	}
+
+	return &DMap{data: intern(d.Data(), pool)}, nil
+}
+
+// intern returns v with its keys and string values replaced by their copies in the pool. The maps are rebuilt and the slices are modified in place.
+func intern(v interface{}, pool *StringPool) interface{} {
+	switch v := v.(type) {
+	case string:
+		return pool.Intern(v)
+
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, elem := range v {
+			m[pool.Intern(k)] = intern(elem, pool)
+		}
+		return m
+
+	case []interface{}:
+		for i, elem := range v {
+			v[i] = intern(elem, pool)
+		}
+		return v
+	}
+
+	return v
+}
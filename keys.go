@@ -0,0 +1,108 @@
+package dmap
+
+import (
+	"fmt"
+	"sort"
+)
+
+// SortedStringKeys returns the keys of the map at a given path sorted lexicographically. Every key has to be a string.
+func (d *DMap) SortedStringKeys(path ...interface{}) ([]string, error) {
+	keys, err := d.mapStringKeys(path)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
+// KeySet returns the keys of the map at a given path as a set. Every key has to be a string.
+func (d *DMap) KeySet(path ...interface{}) (map[string]struct{}, error) {
+	keys, err := d.mapStringKeys(path)
+	if err != nil {
+		return nil, err
+	}
+
+	set := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		set[key] = struct{}{}
+	}
+
+	return set, nil
+}
+
+// mapStringKeys returns the keys of the map at a given path in no particular order, and an error if any of them is not a string.
+func (d *DMap) mapStringKeys(path []interface{}) ([]string, error) {
+	data, err := d.Get(path...)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isMap(data.Data()) {
+		return nil, fmt.Errorf(errorNotMap, path)
+	}
+
+	keys := make([]string, 0, mapLen(data.Data()))
+	eachMapEntry(data.Data(), func(k, _ interface{}) bool {
+		key, ok := k.(string)
+		if !ok {
+			err = fmt.Errorf(errorNonStringKey, k, k, path)
+			return false
+		}
+		keys = append(keys, key)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// UnknownKeys returns the keys of the map at a given path which are not in allowed, sorted lexicographically. Every key has to be a string.
+func (d *DMap) UnknownKeys(allowed []string, path ...interface{}) ([]string, error) {
+	set, err := d.KeySet(path...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range allowed {
+		delete(set, key)
+	}
+
+	unknown := make([]string, 0, len(set))
+	for key := range set {
+		unknown = append(unknown, key)
+	}
+	sort.Strings(unknown)
+
+	return unknown, nil
+}
+
+// Entry is a key of a map along with its value.
+type Entry struct {
+	Key   interface{}
+	Value *DMap
+}
+
+// Entries returns the entries of the map at a given path in the order of Walk, which can then be sorted or accessed by position.
+func (d *DMap) Entries(path ...interface{}) ([]Entry, error) {
+	data, err := d.Get(path...)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isMap(data.Data()) {
+		return nil, fmt.Errorf(errorNotMap, path)
+	}
+
+	keys := sortedKeys(data.Data())
+	entries := make([]Entry, len(keys))
+	for i, key := range keys {
+		value, _ := mapValue(data.Data(), key)
+		entries[i] = Entry{Key: key, Value: d.wrap(value)}
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,77 @@
+package dmap
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var (
+	errorKVSeparator = "line %v has no separator %q"
+	errorKVConflict  = "key %q on line %v conflicts with another key"
+)
+
+// ParseKV returns a new dmap with the key-value lines read from the reader, like in .env or properties files, as a map[string]interface{}. Every line is split at the first sep, and the key and the value are trimmed of white space. Blank lines and lines starting with # are skipped, and a key appearing several times keeps its last value. All the values are kept as strings.
+func ParseKV(r io.Reader, sep string) (*DMap, error) {
+	return parseKV(r, sep, false)
+}
+
+// ParseKVNested is like ParseKV, but splits the keys at dots to build nested maps, so a.b=1 sets "1" at the path a, b. A key which would be both a value and a map, like in a=1 and a.b=2, returns an error.
+func ParseKVNested(r io.Reader, sep string) (*DMap, error) {
+	return parseKV(r, sep, true)
+}
+
+func parseKV(r io.Reader, sep string, nested bool) (*DMap, error) {
+	root := map[string]interface{}{}
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		i := strings.Index(text, sep)
+		if i < 0 {
+			return nil, fmt.Errorf(errorKVSeparator, line, sep)
+		}
+
+		key := strings.TrimSpace(text[:i])
+		value := strings.TrimSpace(text[i+len(sep):])
+
+		if !nested {
+			root[key] = value
+			continue
+		}
+
+		parts := strings.Split(key, ".")
+		m := root
+		for _, part := range parts[:len(parts)-1] {
+			next, ok := m[part]
+			if !ok {
+				next = map[string]interface{}{}
+				m[part] = next
+			}
+
+			child, ok := next.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf(errorKVConflict, key, line)
+			}
+			m = child
+		}
+
+		last := parts[len(parts)-1]
+		if _, ok := m[last].(map[string]interface{}); ok {
+			return nil, fmt.Errorf(errorKVConflict, key, line)
+		}
+		m[last] = value
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return &DMap{data: root}, nil
+}
@@ -0,0 +1,202 @@
+package dmap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// LazyDMap holds JSON bytes which are only decoded when they are accessed. Get skips over the parts of the document which are not on the path, and decodes only the value at the path, so reading a few fields of a large document costs much less than parsing all of it.
+// Nothing is cached, so every call walks and decodes the bytes again. Data which is read several times should be taken once with Get and used as a dmap.
+type LazyDMap struct {
+	raw    json.RawMessage
+	parser *Parser
+}
+
+// ParseJSONLazy returns a new lazy dmap holding a copy of the JSON bytes. The bytes are checked to be valid JSON, but are not decoded.
+func ParseJSONLazy(jsonBytes []byte) (*LazyDMap, error) {
+	return defaultParser.ParseBytesLazy(jsonBytes)
+}
+
+// ParseBytesLazy returns a new lazy dmap holding a copy of the JSON bytes, which are decoded with the options of the parser when they are accessed. MaxBytes applies to the whole document, and MaxDepth to every value returned by Get.
+func (p *Parser) ParseBytesLazy(jsonBytes []byte) (*LazyDMap, error) {
+	if p.MaxBytes > 0 && len(jsonBytes) > p.MaxBytes {
+		return nil, fmt.Errorf(errorMaxBytes, p.MaxBytes)
+	}
+
+	var raw json.RawMessage
+	err := json.Unmarshal(jsonBytes, &raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LazyDMap{raw: raw, parser: p}, nil
+}
+
+// GetRaw returns the JSON bytes of the value at a given path, without decoding them. Only the maps and slices on the path are split into their elements.
+func (l *LazyDMap) GetRaw(path ...interface{}) (json.RawMessage, error) {
+	current := l.raw
+
+	for i, p := range path {
+		trimmed := bytes.TrimSpace(current)
+
+		if len(trimmed) > 0 && trimmed[0] == '{' {
+			key, ok := p.(string)
+			if !ok {
+				return nil, fmt.Errorf(errorExpectedKey, p, p, path[:i+1])
+			}
+
+			var m map[string]json.RawMessage
+			err := json.Unmarshal(trimmed, &m)
+			if err != nil {
+				return nil, err
+			}
+
+			v, ok := m[key]
+			if !ok {
+				return nil, fmt.Errorf(errorKeyNotFound, key, path[:i+1])
+			}
+
+			current = v
+
+		} else if len(trimmed) > 0 && trimmed[0] == '[' {
+			index, ok := p.(int)
+			if !ok {
+				return nil, fmt.Errorf(errorExpectedIndex, p, p, path[:i+1])
+			}
+
+			var s []json.RawMessage
+			err := json.Unmarshal(trimmed, &s)
+			if err != nil {
+				return nil, err
+			}
+
+			if index < 0 || index >= len(s) {
+				return nil, fmt.Errorf(errorIndexOutOfRange, index, path[:i+1])
+			}
+
+			current = s[index]
+
+		} else {
+			return nil, fmt.Errorf(errorUnexpectedType, path[:i+1])
+		}
+	}
+
+	return current, nil
+}
+
+// Get returns a new dmap with the value at a given path decoded, like the Get method of DMap.
+func (l *LazyDMap) Get(path ...interface{}) (*DMap, error) {
+	raw, err := l.GetRaw(path...)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.parser.ParseBytes(raw)
+}
+
+// GetString returns the data at a given path as string.
+func (l *LazyDMap) GetString(path ...interface{}) (string, error) {
+	data, err := l.Get(path...)
+	if err != nil {
+		return "", err
+	}
+
+	s, ok := data.Data().(string)
+	if !ok {
+		return "", fmt.Errorf(errorNotString, path)
+	}
+
+	return s, nil
+}
+
+// GetBool returns the data at a given path as bool.
+func (l *LazyDMap) GetBool(path ...interface{}) (bool, error) {
+	data, err := l.Get(path...)
+	if err != nil {
+		return false, err
+	}
+
+	b, ok := data.Data().(bool)
+	if !ok {
+		return false, fmt.Errorf(errorNotBool, path)
+	}
+
+	return b, nil
+}
+
+// GetFloat64 returns the number at a given path as float64.
+func (l *LazyDMap) GetFloat64(path ...interface{}) (float64, error) {
+	v, err := l.getNumber(path)
+	if err != nil {
+		return 0, err
+	}
+
+	return floatFromNumber(v, 64, path)
+}
+
+// GetInt returns the number at a given path as int.
+func (l *LazyDMap) GetInt(path ...interface{}) (int, error) {
+	v, err := l.getNumber(path)
+	if err != nil {
+		return 0, err
+	}
+
+	i, err := intFromNumber(v, strconv.IntSize, path)
+	return int(i), err
+}
+
+// GetInt64 returns the number at a given path as int64.
+func (l *LazyDMap) GetInt64(path ...interface{}) (int64, error) {
+	v, err := l.getNumber(path)
+	if err != nil {
+		return 0, err
+	}
+
+	return intFromNumber(v, 64, path)
+}
+
+// GetMapSI returns the data at a given path as map[string]interface{}.
+func (l *LazyDMap) GetMapSI(path ...interface{}) (map[string]interface{}, error) {
+	data, err := l.Get(path...)
+	if err != nil {
+		return nil, err
+	}
+
+	m, ok := data.Data().(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf(errorNotMapSI, path)
+	}
+
+	return m, nil
+}
+
+// GetSliceI returns the data at a given path as []interface{}.
+func (l *LazyDMap) GetSliceI(path ...interface{}) ([]interface{}, error) {
+	data, err := l.Get(path...)
+	if err != nil {
+		return nil, err
+	}
+
+	s, ok := data.Data().([]interface{})
+	if !ok {
+		return nil, fmt.Errorf(errorNotSliceI, path)
+	}
+
+	return s, nil
+}
+
+// getNumber returns the data at a given path, and an error if it is not a number.
+func (l *LazyDMap) getNumber(path []interface{}) (interface{}, error) {
+	data, err := l.Get(path...)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isNumber(data.Data()) {
+		return nil, fmt.Errorf(errorNotNumber, path)
+	}
+
+	return data.Data(), nil
+}
@@ -0,0 +1,147 @@
+package dmap
+
+import (
+	"fmt"
+)
+
+var (
+	errorMergeNotMap = "cannot merge: data at root is not a map"
+)
+
+// MergeFunc merges the data of other into the dmap. Maps present on both sides are merged recursively, values present only in other are added, and whenever both sides hold a value at the same path and at least one of them is not a map, resolve is called with that path and both values: its return value is stored at the path.
+// Both dmaps must hold a map at the root. Values taken from other are not copied, so they are shared between both dmaps after the merge.
+func (d *DMap) MergeFunc(other *DMap, resolve func(path []interface{}, a, b interface{}) interface{}) error {
+	if d.isReadOnly() {
+		return ErrReadOnly
+	}
+
+	if !isMap(d.Data()) || !isMap(other.Data()) {
+		return fmt.Errorf(errorMergeNotMap)
+	}
+
+	return mergeMaps(d.Data(), other.Data(), nil, resolve, d.notify)
+}
+
+// Overlay returns a new dmap with the layers merged in order, so every layer overrides the ones before it, like when assembling config from defaults, files, environment variables and flags. The layers are merged as in MergeFunc with the value of the later layer always kept, so a slice replaces the slice of an earlier layer instead of being appended to it.
+// Every layer must hold a map at the root, and layers without data are skipped. The data is copied, so the layers are not modified and do not share data with the result. Without any layer with data, the result holds an empty map[string]interface{}.
+func Overlay(layers ...*DMap) (*DMap, error) {
+	var data interface{}
+
+	for _, layer := range layers {
+		if !layer.HasData() {
+			continue
+		}
+
+		if !isMap(layer.Data()) {
+			return nil, fmt.Errorf(errorMergeNotMap)
+		}
+
+		if data == nil {
+			data = deepCopy(layer.Data())
+			continue
+		}
+
+		err := mergeMaps(data, deepCopy(layer.Data()), nil, func(path []interface{}, a, b interface{}) interface{} {
+			return b
+		}, func(path []interface{}, oldVal, newVal interface{}) {})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+
+	return &DMap{data: data}, nil
+}
+
+// mergeMaps merges src into dst as described in MergeFunc, calling notify for every value set in dst.
+func mergeMaps(dst, src interface{}, path []interface{}, resolve func(path []interface{}, a, b interface{}) interface{}, notify func(path []interface{}, oldVal, newVal interface{})) error {
+	var err error
+
+	eachMapEntry(src, func(key, b interface{}) bool {
+		keyPath := appendPath(path, key)
+
+		a, ok := mapValue(dst, key)
+		if isMap(a) && isMap(b) {
+			err = mergeMaps(a, b, keyPath, resolve, notify)
+			return err == nil
+		}
+
+		v := b
+		if ok {
+			v = resolve(keyPath, a, b)
+		}
+
+		err = setMapValue(dst, key, v, keyPath)
+		if err == nil {
+			notify(keyPath, a, v)
+		}
+
+		return err == nil
+	})
+
+	return err
+}
+
+// Subtract returns a copy of the dmap with every path present in other removed. Maps present on both sides are subtracted recursively, and so are slices: an element of a slice is removed if it is equal to any element of the slice at the same path in other.
+// Elements are compared by value, with numbers compared regardless of their types and maps regardless of their key types. A value present in both dmaps which is not a map or a slice on both sides is removed whatever its value is.
+func (d *DMap) Subtract(other *DMap) *DMap {
+	data := deepCopy(d.Data())
+	if other.HasData() {
+		data = subtract(data, other.Data())
+	}
+
+	return &DMap{data: data}
+}
+
+// subtract removes the paths present in b from a, which is modified in place, and returns the result.
+func subtract(a, b interface{}) interface{} {
+	if isMap(a) && isMap(b) {
+		eachMapEntry(b, func(key, y interface{}) bool {
+			x, ok := mapValue(a, key)
+			if !ok {
+				return true
+			}
+
+			if (isMap(x) && isMap(y)) || (isSlice(x) && isSlice(y)) {
+				setMapValue(a, key, subtract(x, y), nil)
+			} else {
+				deleteMapValue(a, key)
+			}
+			return true
+		})
+		return a
+	}
+
+	if x, ok := a.([]interface{}); ok {
+		if y, ok := b.([]interface{}); ok {
+			kept := x[:0]
+			for _, elem := range x {
+				if !sliceContains(y, elem) {
+					kept = append(kept, elem)
+				}
+			}
+			return kept
+		}
+	}
+
+	return nil
+}
+
+// isSlice checks if v is a []interface{}.
+func isSlice(v interface{}) bool {
+	_, ok := v.([]interface{})
+	return ok
+}
+
+// sliceContains checks if any element of s is equal to v.
+func sliceContains(s []interface{}, v interface{}) bool {
+	for _, elem := range s {
+		if equal(elem, v) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,474 @@
+package dmap
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	errorNotNumber     = "data at %v is not a number"
+	errorNotInteger    = "number %v at %v is not an integer"
+	errorNumberRange   = "number %v at %v is out of range for %v"
+	errorNumericString = "string %q at %v is not a number"
+)
+
+// All the numeric getters accept any Go numeric type as well as json.Number. The integer getters fail if the number has a fractional part, and every getter fails if the number does not fit in the returned type. Strings are only converted by the getters whose name ends with FromString, which require a string, and by the ones whose name ends with Loose, which accept a number or a string. These getters accept strings written like JSON numbers, which are then converted like json.Number.
+
+// GetFloat64 returns the number at a given path as float64.
+func (d *DMap) GetFloat64(path ...interface{}) (float64, error) {
+	v, err := d.getNumber(path)
+	if err != nil {
+		return 0, err
+	}
+
+	return floatFromNumber(v, 64, path)
+}
+
+// GetFloat32 returns the number at a given path as float32.
+func (d *DMap) GetFloat32(path ...interface{}) (float32, error) {
+	v, err := d.getNumber(path)
+	if err != nil {
+		return 0, err
+	}
+
+	f, err := floatFromNumber(v, 32, path)
+	return float32(f), err
+}
+
+// GetInt returns the number at a given path as int.
+func (d *DMap) GetInt(path ...interface{}) (int, error) {
+	v, err := d.getNumber(path)
+	if err != nil {
+		return 0, err
+	}
+
+	i, err := intFromNumber(v, strconv.IntSize, path)
+	return int(i), err
+}
+
+// GetInt32 returns the number at a given path as int32.
+func (d *DMap) GetInt32(path ...interface{}) (int32, error) {
+	v, err := d.getNumber(path)
+	if err != nil {
+		return 0, err
+	}
+
+	i, err := intFromNumber(v, 32, path)
+	return int32(i), err
+}
+
+// GetInt64 returns the number at a given path as int64.
+func (d *DMap) GetInt64(path ...interface{}) (int64, error) {
+	v, err := d.getNumber(path)
+	if err != nil {
+		return 0, err
+	}
+
+	return intFromNumber(v, 64, path)
+}
+
+// GetUint returns the number at a given path as uint.
+func (d *DMap) GetUint(path ...interface{}) (uint, error) {
+	v, err := d.getNumber(path)
+	if err != nil {
+		return 0, err
+	}
+
+	u, err := uintFromNumber(v, strconv.IntSize, path)
+	return uint(u), err
+}
+
+// GetUint64 returns the number at a given path as uint64.
+func (d *DMap) GetUint64(path ...interface{}) (uint64, error) {
+	v, err := d.getNumber(path)
+	if err != nil {
+		return 0, err
+	}
+
+	return uintFromNumber(v, 64, path)
+}
+
+// GetIntFromString returns the string at a given path parsed as a number, as int.
+func (d *DMap) GetIntFromString(path ...interface{}) (int, error) {
+	v, err := d.getNumberString(path, false)
+	if err != nil {
+		return 0, err
+	}
+
+	i, err := intFromNumber(v, strconv.IntSize, path)
+	return int(i), err
+}
+
+// GetInt64FromString returns the string at a given path parsed as a number, as int64, like the 64-bit integers of protojson.
+func (d *DMap) GetInt64FromString(path ...interface{}) (int64, error) {
+	v, err := d.getNumberString(path, false)
+	if err != nil {
+		return 0, err
+	}
+
+	return intFromNumber(v, 64, path)
+}
+
+// GetIntLoose returns the number or the numeric string at a given path as int.
+func (d *DMap) GetIntLoose(path ...interface{}) (int, error) {
+	v, err := d.getNumberString(path, true)
+	if err != nil {
+		return 0, err
+	}
+
+	i, err := intFromNumber(v, strconv.IntSize, path)
+	return int(i), err
+}
+
+// GetFloat64Loose returns the number or the numeric string at a given path as float64.
+func (d *DMap) GetFloat64Loose(path ...interface{}) (float64, error) {
+	v, err := d.getNumberString(path, true)
+	if err != nil {
+		return 0, err
+	}
+
+	return floatFromNumber(v, 64, path)
+}
+
+// GetBigRat returns the number or the numeric string at a given path as an exact big.Rat. A json.Number or a string keeps every digit it was written with, so the data should be parsed with UseNumber or hold the numbers as strings, since a float64 may have lost precision already when it was parsed. A float64 is converted from the shortest decimal which rounds to it, so 0.1 becomes 1/10.
+func (d *DMap) GetBigRat(path ...interface{}) (*big.Rat, error) {
+	v, err := d.getNumberString(path, true)
+	if err != nil {
+		return nil, err
+	}
+
+	r, ok := new(big.Rat).SetString(bigNumberString(v))
+	if !ok {
+		return nil, fmt.Errorf(errorNotNumber, path)
+	}
+
+	return r, nil
+}
+
+// GetBigFloat returns the number or the numeric string at a given path as a big.Float, like GetBigRat. The precision is chosen to hold every decimal digit of the number, with at least the 53 bits of a float64, but a decimal fraction like 0.1 has no exact binary value and is rounded to that precision.
+func (d *DMap) GetBigFloat(path ...interface{}) (*big.Float, error) {
+	v, err := d.getNumberString(path, true)
+	if err != nil {
+		return nil, err
+	}
+
+	s := bigNumberString(v)
+	prec := uint(len(s)) * 4
+	if prec < 53 {
+		prec = 53
+	}
+
+	f, _, err := big.ParseFloat(s, 10, prec, big.ToNearestEven)
+	if err != nil {
+		return nil, fmt.Errorf(errorNotNumber, path)
+	}
+
+	return f, nil
+}
+
+// GetFloat64Matrix returns the []interface{} of []interface{} of numbers at a given path as [][]float64. The rows may have different lengths, and the error for an element which is not a number has its row and column in the path.
+func (d *DMap) GetFloat64Matrix(path ...interface{}) ([][]float64, error) {
+	rows, err := d.matrixRows(path)
+	if err != nil {
+		return nil, err
+	}
+
+	matrix := make([][]float64, len(rows))
+	for i, row := range rows {
+		matrix[i] = make([]float64, len(row))
+		for j, elem := range row {
+			matrix[i][j], err = floatFromNumber(elem, 64, appendPath(path, i, j))
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return matrix, nil
+}
+
+// GetIntMatrix is like GetFloat64Matrix, but returns the numbers as int.
+func (d *DMap) GetIntMatrix(path ...interface{}) ([][]int, error) {
+	rows, err := d.matrixRows(path)
+	if err != nil {
+		return nil, err
+	}
+
+	matrix := make([][]int, len(rows))
+	for i, row := range rows {
+		matrix[i] = make([]int, len(row))
+		for j, elem := range row {
+			n, err := intFromNumber(elem, strconv.IntSize, appendPath(path, i, j))
+			if err != nil {
+				return nil, err
+			}
+			matrix[i][j] = int(n)
+		}
+	}
+
+	return matrix, nil
+}
+
+// matrixRows returns the rows of the []interface{} of []interface{} at a given path.
+func (d *DMap) matrixRows(path []interface{}) ([][]interface{}, error) {
+	data, err := d.GetSliceI(path...)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([][]interface{}, len(data))
+	for i, elem := range data {
+		row, ok := elem.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf(errorNotSliceI, appendPath(path, i))
+		}
+		rows[i] = row
+	}
+
+	return rows, nil
+}
+
+// getNumber returns the data at a given path, and an error if it is not a number.
+func (d *DMap) getNumber(path []interface{}) (interface{}, error) {
+	data, err := d.Get(path...)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isNumber(data.Data()) {
+		return nil, fmt.Errorf(errorNotNumber, path)
+	}
+
+	return data.Data(), nil
+}
+
+// getNumberString returns the string at a given path as a json.Number, and an error if it is not a string written like a JSON number. If acceptNumbers is true, a number is returned as it is.
+func (d *DMap) getNumberString(path []interface{}, acceptNumbers bool) (interface{}, error) {
+	data, err := d.Get(path...)
+	if err != nil {
+		return nil, err
+	}
+
+	if acceptNumbers && isNumber(data.Data()) {
+		return data.Data(), nil
+	}
+
+	s, ok := data.Data().(string)
+	if !ok {
+		if acceptNumbers {
+			return nil, fmt.Errorf(errorNotNumber, path)
+		}
+		return nil, fmt.Errorf(errorNotString, path)
+	}
+
+	if s == "" {
+		return nil, fmt.Errorf(errorNumericString, s, path)
+	}
+
+	// JSON allows whitespace around a value, so the first and the last characters are checked as well.
+	last := s[len(s)-1]
+	if (s[0] != '-' && (s[0] < '0' || s[0] > '9')) || last < '0' || last > '9' || !json.Valid([]byte(s)) {
+		return nil, fmt.Errorf(errorNumericString, s, path)
+	}
+
+	return json.Number(s), nil
+}
+
+// bigNumberString returns the number v written in decimal, with the shortest representation for floats.
+func bigNumberString(v interface{}) string {
+	switch v := v.(type) {
+	case json.Number:
+		return string(v)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32)
+	}
+
+	return fmt.Sprint(v)
+}
+
+// floatFromNumber converts the number v to a float of the given size in bits. The path of v is used for errors.
+func floatFromNumber(v interface{}, bits int, path []interface{}) (float64, error) {
+	if n, ok := v.(json.Number); ok {
+		f, err := n.Float64()
+		if err != nil {
+			return 0, fmt.Errorf(errorNumberRange, v, path, "float"+strconv.Itoa(bits))
+		}
+		v = f
+	}
+
+	f, ok := toFloat64(v)
+	if !ok {
+		return 0, fmt.Errorf(errorNotNumber, path)
+	}
+
+	if bits == 32 && math.Abs(f) > math.MaxFloat32 && !math.IsInf(f, 0) {
+		return 0, fmt.Errorf(errorNumberRange, v, path, "float32")
+	}
+
+	return f, nil
+}
+
+// intFromNumber converts the number v to a signed integer of the given size in bits, which has to hold its value exactly. The path of v is used for errors.
+func intFromNumber(v interface{}, bits int, path []interface{}) (int64, error) {
+	min := int64(math.MinInt64) >> (64 - bits)
+	max := ^min
+	target := "int" + strconv.Itoa(bits)
+
+	if n, ok := v.(json.Number); ok {
+		s, err := jsonInteger(n, path)
+		if err != nil {
+			return 0, err
+		}
+
+		i, err := strconv.ParseInt(s, 10, bits)
+		if err != nil {
+			return 0, fmt.Errorf(errorNumberRange, v, path, target)
+		}
+		return i, nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i := rv.Int()
+		if i < min || i > max {
+			return 0, fmt.Errorf(errorNumberRange, v, path, target)
+		}
+		return i, nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u := rv.Uint()
+		if u > uint64(max) {
+			return 0, fmt.Errorf(errorNumberRange, v, path, target)
+		}
+		return int64(u), nil
+
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if math.IsInf(f, 0) || f < float64(min) || f >= -float64(min) {
+			return 0, fmt.Errorf(errorNumberRange, v, path, target)
+		}
+		if f != math.Trunc(f) {
+			return 0, fmt.Errorf(errorNotInteger, v, path)
+		}
+		return int64(f), nil
+	}
+
+	return 0, fmt.Errorf(errorNotNumber, path)
+}
+
+// uintFromNumber converts the number v to an unsigned integer of the given size in bits, which has to hold its value exactly. The path of v is used for errors.
+func uintFromNumber(v interface{}, bits int, path []interface{}) (uint64, error) {
+	max := uint64(math.MaxUint64) >> (64 - bits)
+	target := "uint" + strconv.Itoa(bits)
+
+	if n, ok := v.(json.Number); ok {
+		s, err := jsonInteger(n, path)
+		if err != nil {
+			return 0, err
+		}
+
+		u, err := strconv.ParseUint(s, 10, bits)
+		if err != nil {
+			return 0, fmt.Errorf(errorNumberRange, v, path, target)
+		}
+		return u, nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i := rv.Int()
+		if i < 0 || uint64(i) > max {
+			return 0, fmt.Errorf(errorNumberRange, v, path, target)
+		}
+		return uint64(i), nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u := rv.Uint()
+		if u > max {
+			return 0, fmt.Errorf(errorNumberRange, v, path, target)
+		}
+		return u, nil
+
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if math.IsInf(f, 0) || f < 0 || f >= math.Ldexp(1, bits) {
+			return 0, fmt.Errorf(errorNumberRange, v, path, target)
+		}
+		if f != math.Trunc(f) {
+			return 0, fmt.Errorf(errorNotInteger, v, path)
+		}
+		return uint64(f), nil
+	}
+
+	return 0, fmt.Errorf(errorNotNumber, path)
+}
+
+// jsonInteger returns the JSON number n written as a decimal integer, like "-1000" for -1e3 or -1.0e3, and an error if its value is not an integer. It is exact, unlike a conversion to float64 which may round a number to an integer or into range.
+// An integer of more than 20 digits is returned as a 21-digit one, which fits in no integer type, so that a large exponent is not expanded.
+func jsonInteger(n json.Number, path []interface{}) (string, error) {
+	s := string(n)
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+
+	mantissa, exponent, expDigits := s, "0", "0"
+	if e := strings.IndexAny(s, "eE"); e >= 0 {
+		mantissa, exponent, expDigits = s[:e], s[e+1:], s[e+1:]
+		if strings.HasPrefix(expDigits, "+") || strings.HasPrefix(expDigits, "-") {
+			expDigits = expDigits[1:]
+		}
+	}
+	whole, fraction, hasFraction := strings.Cut(mantissa, ".")
+
+	if !isDigits(whole) || (len(whole) > 1 && whole[0] == '0') || (hasFraction && !isDigits(fraction)) || !isDigits(expDigits) {
+		return "", fmt.Errorf(errorNotNumber, path)
+	}
+
+	digits := strings.TrimLeft(whole+fraction, "0")
+	if digits == "" {
+		return "0", nil
+	}
+	trimmed := strings.TrimRight(digits, "0")
+
+	// Exponents beyond 6 digits are clamped, as the number then has far more digits than any integer type or far fewer than one.
+	exp, err := strconv.Atoi(exponent)
+	if err != nil || exp > 999999 || exp < -999999 {
+		exp = 999999
+		if strings.HasPrefix(exponent, "-") {
+			exp = -999999
+		}
+	}
+	exp += len(digits) - len(trimmed) - len(fraction)
+
+	if exp < 0 {
+		return "", fmt.Errorf(errorNotInteger, n, path)
+	}
+	if len(trimmed)+exp > 20 {
+		return sign + "1" + strings.Repeat("0", 20), nil
+	}
+
+	return sign + trimmed + strings.Repeat("0", exp), nil
+}
+
+// isDigits checks if s is made of one or more decimal digits.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
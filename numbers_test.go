@@ -0,0 +1,197 @@
+package dmap
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNumberRanges(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		get     func(d *DMap) (interface{}, error)
+		want    interface{}
+		wantErr bool
+	}{
+		{
+			name: "int32 max",
+			in:   `2147483647`,
+			get:  func(d *DMap) (interface{}, error) { return d.GetInt32() },
+			want: int32(math.MaxInt32),
+		},
+		{
+			name:    "int32 above max",
+			in:      `2147483648`,
+			get:     func(d *DMap) (interface{}, error) { return d.GetInt32() },
+			wantErr: true,
+		},
+		{
+			name: "int32 min",
+			in:   `-2147483648`,
+			get:  func(d *DMap) (interface{}, error) { return d.GetInt32() },
+			want: int32(math.MinInt32),
+		},
+		{
+			name:    "int32 below min",
+			in:      `-2147483649`,
+			get:     func(d *DMap) (interface{}, error) { return d.GetInt32() },
+			wantErr: true,
+		},
+		{
+			name: "int64 max",
+			in:   `9223372036854775807`,
+			get:  func(d *DMap) (interface{}, error) { return d.GetInt64() },
+			want: int64(math.MaxInt64),
+		},
+		{
+			name:    "int64 above max",
+			in:      `9223372036854775808`,
+			get:     func(d *DMap) (interface{}, error) { return d.GetInt64() },
+			wantErr: true,
+		},
+		{
+			name: "int64 min",
+			in:   `-9223372036854775808`,
+			get:  func(d *DMap) (interface{}, error) { return d.GetInt64() },
+			want: int64(math.MinInt64),
+		},
+		{
+			name:    "int64 below min",
+			in:      `-9223372036854775809`,
+			get:     func(d *DMap) (interface{}, error) { return d.GetInt64() },
+			wantErr: true,
+		},
+		{
+			name:    "int fraction",
+			in:      `1.5`,
+			get:     func(d *DMap) (interface{}, error) { return d.GetInt() },
+			wantErr: true,
+		},
+		{
+			name: "uint zero",
+			in:   `0`,
+			get:  func(d *DMap) (interface{}, error) { return d.GetUint() },
+			want: uint(0),
+		},
+		{
+			name:    "uint negative",
+			in:      `-1`,
+			get:     func(d *DMap) (interface{}, error) { return d.GetUint() },
+			wantErr: true,
+		},
+		{
+			name: "uint64 max",
+			in:   `18446744073709551615`,
+			get:  func(d *DMap) (interface{}, error) { return d.GetUint64() },
+			want: uint64(math.MaxUint64),
+		},
+		{
+			name:    "uint64 above max",
+			in:      `18446744073709551616`,
+			get:     func(d *DMap) (interface{}, error) { return d.GetUint64() },
+			wantErr: true,
+		},
+		{
+			name: "float32 max",
+			in:   `3.4028234663852886e38`,
+			get:  func(d *DMap) (interface{}, error) { return d.GetFloat32() },
+			want: float32(math.MaxFloat32),
+		},
+		{
+			name:    "float32 above max",
+			in:      `3.5e38`,
+			get:     func(d *DMap) (interface{}, error) { return d.GetFloat32() },
+			wantErr: true,
+		},
+		{
+			name:    "float32 below min",
+			in:      `-3.5e38`,
+			get:     func(d *DMap) (interface{}, error) { return d.GetFloat32() },
+			wantErr: true,
+		},
+		{
+			name:    "float64 above max",
+			in:      `1e400`,
+			get:     func(d *DMap) (interface{}, error) { return d.GetFloat64() },
+			wantErr: true,
+		},
+	}
+
+	parser := &Parser{UseNumber: true}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d, err := parser.ParseString(test.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := test.get(d)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got error %v, want error %v", err, test.wantErr)
+			}
+			if !test.wantErr && got != test.want {
+				t.Errorf("got %v of type %T, want %v of type %T", got, got, test.want, test.want)
+			}
+		})
+	}
+}
+
+func TestNumberRangesGoTypes(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      interface{}
+		get     func(d *DMap) (interface{}, error)
+		want    interface{}
+		wantErr bool
+	}{
+		{
+			name: "uint64 max to uint64",
+			in:   uint64(math.MaxUint64),
+			get:  func(d *DMap) (interface{}, error) { return d.GetUint64() },
+			want: uint64(math.MaxUint64),
+		},
+		{
+			name:    "uint64 max to int64",
+			in:      uint64(math.MaxUint64),
+			get:     func(d *DMap) (interface{}, error) { return d.GetInt64() },
+			wantErr: true,
+		},
+		{
+			name:    "int8 negative to uint",
+			in:      int8(-1),
+			get:     func(d *DMap) (interface{}, error) { return d.GetUint() },
+			wantErr: true,
+		},
+		{
+			name: "int64 to int32",
+			in:   int64(math.MinInt32),
+			get:  func(d *DMap) (interface{}, error) { return d.GetInt32() },
+			want: int32(math.MinInt32),
+		},
+		{
+			name:    "float64 above int64",
+			in:      float64(1 << 63),
+			get:     func(d *DMap) (interface{}, error) { return d.GetInt64() },
+			wantErr: true,
+		},
+		{
+			name:    "float64 above float32",
+			in:      math.MaxFloat64,
+			get:     func(d *DMap) (interface{}, error) { return d.GetFloat32() },
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.get(Init(test.in))
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got error %v, want error %v", err, test.wantErr)
+			}
+			if !test.wantErr && got != test.want {
+				t.Errorf("got %v of type %T, want %v of type %T", got, got, test.want, test.want)
+			}
+		})
+	}
+}
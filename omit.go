@@ -0,0 +1,90 @@
+package dmap
+
+// Omit selects the empty values left out by ToJSONBytesOmitEmpty. The values can be combined with |.
+type Omit int
+
+const (
+	// OmitNil leaves out the nil values.
+	OmitNil Omit = 1 << iota
+	// OmitEmptyStrings leaves out the empty strings.
+	OmitEmptyStrings
+	// OmitEmptyMaps leaves out the maps without any entries.
+	OmitEmptyMaps
+	// OmitEmptySlices leaves out the slices without any elements.
+	OmitEmptySlices
+
+	// OmitAll leaves out every kind of empty value.
+	OmitAll = OmitNil | OmitEmptyStrings | OmitEmptyMaps | OmitEmptySlices
+)
+
+// ToJSONBytesOmitEmpty returns the data of the dmap encoded like JSONCodec does, leaving out the map entries whose values are empty, like the omitempty option of struct tags. The kinds of empty values to leave out are combined from omit, and every kind is left out if omit is not given.
+// A map whose entries are all left out becomes empty and is left out in turn. The elements of slices are never left out, so that the other elements keep their indices, but the maps inside them are trimmed as well. The dmap is not modified.
+func (d *DMap) ToJSONBytesOmitEmpty(omit ...Omit) ([]byte, error) {
+	var flags Omit
+	for _, o := range omit {
+		flags |= o
+	}
+	if len(omit) == 0 {
+		flags = OmitAll
+	}
+
+	return JSONCodec.Encode(omitEmpty(d.Data(), flags))
+}
+
+// omitEmpty returns a copy of v without the map entries whose values are empty according to flags.
+func omitEmpty(v interface{}, flags Omit) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, elem := range v {
+			elem = omitEmpty(elem, flags)
+			if !isEmpty(elem, flags) {
+				m[k] = elem
+			}
+		}
+		return m
+
+	case map[interface{}]interface{}:
+		m := make(map[interface{}]interface{}, len(v))
+		for k, elem := range v {
+			elem = omitEmpty(elem, flags)
+			if !isEmpty(elem, flags) {
+				m[k] = elem
+			}
+		}
+		return m
+
+	case *OrderedMap:
+		m := NewOrderedMap()
+		for _, k := range v.keys {
+			elem := omitEmpty(v.values[k], flags)
+			if !isEmpty(elem, flags) {
+				m.Set(k, elem)
+			}
+		}
+		return m
+
+	case []interface{}:
+		s := make([]interface{}, len(v))
+		for i, elem := range v {
+			s[i] = omitEmpty(elem, flags)
+		}
+		return s
+	}
+
+	return v
+}
+
+// isEmpty checks if v is one of the empty values selected by flags.
+func isEmpty(v interface{}, flags Omit) bool {
+	switch v := v.(type) {
+	case nil:
+		return flags&OmitNil != 0
+	case string:
+		return v == "" && flags&OmitEmptyStrings != 0
+	case []interface{}:
+		return len(v) == 0 && flags&OmitEmptySlices != 0
+	}
+
+	return isMap(v) && mapLen(v) == 0 && flags&OmitEmptyMaps != 0
+}
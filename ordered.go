@@ -0,0 +1,166 @@
+package dmap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// OrderedMap is a string-keyed map which remembers the order in which its keys were added. It is produced by ParseJSONOrdered, is treated as a map by all the dmap methods, and is marshalled to JSON with its keys in order.
+type OrderedMap struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+// NewOrderedMap returns a new empty ordered map.
+func NewOrderedMap() *OrderedMap {
+	return &OrderedMap{
+		values: map[string]interface{}{},
+	}
+}
+
+// Len returns the number of keys in the map.
+func (m *OrderedMap) Len() int {
+	return len(m.keys)
+}
+
+// Keys returns the keys of the map in order.
+func (m *OrderedMap) Keys() []string {
+	return append([]string(nil), m.keys...)
+}
+
+// Value returns the value stored under key and whether the key exists.
+func (m *OrderedMap) Value(key string) (interface{}, bool) {
+	v, ok := m.values[key]
+	return v, ok
+}
+
+// Set stores v under key. A new key is added after the existing ones, while an existing key keeps its position.
+func (m *OrderedMap) Set(key string, v interface{}) {
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = v
+}
+
+// Delete removes key from the map.
+func (m *OrderedMap) Delete(key string) {
+	if _, ok := m.values[key]; !ok {
+		return
+	}
+
+	delete(m.values, key)
+	for i, k := range m.keys {
+		if k == key {
+			m.keys = append(m.keys[:i], m.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+// toMapSI returns a map[string]interface{} with the entries of the map.
+func (m *OrderedMap) toMapSI() map[string]interface{} {
+	mapSI := make(map[string]interface{}, len(m.values))
+	for k, v := range m.values {
+		mapSI[k] = v
+	}
+	return mapSI
+}
+
+// MarshalJSON returns the map as a JSON object with the keys in order.
+func (m *OrderedMap) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+
+	for i, key := range m.keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+
+		v, err := stringKeys(m.values[key], []interface{}{key}, true)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+
+		b.Write(k)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// ParseJSONOrdered returns a new dmap with the JSON bytes unmarshalled, storing every object as an *OrderedMap so that the order of its keys is kept. If a key appears several times in an object, its last value is kept at the position of its first occurrence.
+func ParseJSONOrdered(jsonBytes []byte) (*DMap, error) {
+	decoder := json.NewDecoder(bytes.NewReader(jsonBytes))
+
+	v, err := decodeOrdered(decoder)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf(errorTrailingData)
+	}
+
+	return &DMap{data: v}, nil
+}
+
+func decodeOrdered(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err == io.EOF {
+		return nil, io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch token {
+	case json.Delim('{'):
+		m := NewOrderedMap()
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+
+			v, err := decodeOrdered(decoder)
+			if err != nil {
+				return nil, err
+			}
+
+			m.Set(key.(string), v)
+		}
+
+		_, err = decoder.Token()
+		return m, err
+
+	case json.Delim('['):
+		s := []interface{}{}
+		for decoder.More() {
+			v, err := decodeOrdered(decoder)
+			if err != nil {
+				return nil, err
+			}
+
+			s = append(s, v)
+		}
+
+		_, err = decoder.Token()
+		return s, err
+	}
+
+	return token, nil
+}
@@ -0,0 +1,156 @@
+package dmap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+var (
+	errorTrailingData = "invalid data after top-level value"
+	errorMaxBytes     = "data exceeds the maximum size of %v bytes"
+	errorMaxDepth     = "data exceeds the maximum depth of %v"
+)
+
+// Parser holds the options used to parse JSON into a dmap. The zero value parses like encoding/json, and a parser can be reused for any number of documents.
+type Parser struct {
+	// UseNumber decodes numbers as json.Number instead of float64.
+	UseNumber bool
+
+	// MaxDepth is the maximum nesting depth of the parsed data, where a map or slice of scalars has a depth of 1. Zero means no limit.
+	MaxDepth int
+
+	// MaxBytes is the maximum number of bytes read for a document. Zero means no limit.
+	MaxBytes int
+
+	// DisallowUnknownFields makes GetInto on the parsed dmaps return an error for a map key which matches no field of the struct, like GetIntoStrict.
+	DisallowUnknownFields bool
+}
+
+// defaultParser is used by the package-level parse functions.
+var defaultParser = &Parser{}
+
+// ParseBytes returns a new dmap with the JSON bytes unmarshalled.
+func (p *Parser) ParseBytes(jsonBytes []byte) (*DMap, error) {
+	if p.MaxBytes > 0 && len(jsonBytes) > p.MaxBytes {
+		return nil, fmt.Errorf(errorMaxBytes, p.MaxBytes)
+	}
+
+	var v interface{}
+
+	if p.UseNumber {
+		decoder := json.NewDecoder(bytes.NewReader(jsonBytes))
+		decoder.UseNumber()
+		err := decoder.Decode(&v)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := decoder.Token(); err != io.EOF {
+			return nil, fmt.Errorf(errorTrailingData)
+		}
+	} else {
+		err := json.Unmarshal(jsonBytes, &v)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return p.result(v)
+}
+
+// ParseString returns a new dmap with the JSON string unmarshalled.
+func (p *Parser) ParseString(jsonString string) (*DMap, error) {
+	return p.ParseBytes([]byte(jsonString))
+}
+
+// ParseBuffer returns a new dmap with the first JSON value read from the buffer unmarshalled.
+func (p *Parser) ParseBuffer(jsonBuffer io.Reader) (*DMap, error) {
+	var v interface{}
+	err := p.decoder(jsonBuffer).Decode(&v)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.result(v)
+}
+
+// ParseMulti returns a new dmap for every JSON value read from the buffer until its end. The values may follow each other directly or be separated by whitespace. MaxBytes applies to the whole buffer and MaxDepth to every value.
+// If a value cannot be decoded, the dmaps of the values before it are returned along with the error.
+func (p *Parser) ParseMulti(jsonBuffer io.Reader) ([]*DMap, error) {
+	decoder := p.decoder(jsonBuffer)
+
+	var dmaps []*DMap
+	for {
+		var v interface{}
+		err := decoder.Decode(&v)
+		if err == io.EOF {
+			return dmaps, nil
+		}
+		if err != nil {
+			return dmaps, err
+		}
+
+		d, err := p.result(v)
+		if err != nil {
+			return dmaps, err
+		}
+
+		dmaps = append(dmaps, d)
+	}
+}
+
+// decoder returns a JSON decoder for the reader configured with the options of the parser.
+func (p *Parser) decoder(r io.Reader) *json.Decoder {
+	if p.MaxBytes > 0 {
+		r = &limitedReader{r: r, max: p.MaxBytes}
+	}
+
+	decoder := json.NewDecoder(r)
+	if p.UseNumber {
+		decoder.UseNumber()
+	}
+
+	return decoder
+}
+
+// result checks the parsed data against the limits of the parser and wraps it in a dmap.
+func (p *Parser) result(v interface{}) (*DMap, error) {
+	if p.MaxDepth > 0 && depth(v) > p.MaxDepth {
+		return nil, fmt.Errorf(errorMaxDepth, p.MaxDepth)
+	}
+
+	d := &DMap{data: v}
+	if p.DisallowUnknownFields {
+		d.setOptions(func(o *dmapOptions) { o.disallowUnknownFields = true })
+	}
+
+	return d, nil
+}
+
+// limitedReader reads at most max bytes from r, and returns an error if r has more data after that.
+type limitedReader struct {
+	r    io.Reader
+	read int
+	max  int
+}
+
+func (l *limitedReader) Read(b []byte) (int, error) {
+	if l.read >= l.max {
+		var extra [1]byte
+		n, err := l.r.Read(extra[:])
+		if n > 0 {
+			return 0, fmt.Errorf(errorMaxBytes, l.max)
+		}
+		return 0, err
+	}
+
+	if len(b) > l.max-l.read {
+		b = b[:l.max-l.read]
+	}
+
+	n, err := l.r.Read(b)
+	l.read += n
+	return n, err
+}
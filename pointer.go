@@ -0,0 +1,192 @@
+package dmap
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	errorInvalidPointer = "invalid JSON pointer %q"
+	errorDeleteRoot     = "cannot delete the whole data"
+)
+
+// ParsePointer splits a JSON pointer (RFC 6901) into its reference tokens, with "~1" unescaped to "/" and "~0" to "~". The empty pointer refers to the whole data and has no tokens.
+func ParsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+
+	if pointer[0] != '/' {
+		return nil, fmt.Errorf(errorInvalidPointer, pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		if !strings.Contains(token, "~") {
+			continue
+		}
+
+		for j := 0; j < len(token); j++ {
+			if token[j] == '~' && (j+1 == len(token) || (token[j+1] != '0' && token[j+1] != '1')) {
+				return nil, fmt.Errorf(errorInvalidPointer, pointer)
+			}
+		}
+
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+
+	return tokens, nil
+}
+
+// GetByPointer returns the data at a JSON pointer (RFC 6901), like "/root/contents/1".
+func (d *DMap) GetByPointer(pointer string) (*DMap, error) {
+	tokens, err := ParsePointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.GetByPointerTokens(tokens)
+}
+
+// GetByPointerTokens returns the data at the JSON pointer made of the given reference tokens, as returned by ParsePointer. The tokens are used as they are, so parsing a pointer once and reusing its tokens avoids parsing it again for every lookup.
+func (d *DMap) GetByPointerTokens(tokens []string) (*DMap, error) {
+	if !d.HasData() && len(tokens) != 0 {
+		return nil, d.fail(stringsToPath(tokens), fmt.Errorf(errorEmptyData))
+	}
+
+	currentData := d.Data()
+
+	for i, token := range tokens {
+		if isMap(currentData) {
+			v, ok := mapValue(currentData, token)
+			if !ok {
+				return nil, d.fail(stringsToPath(tokens), fmt.Errorf(errorKeyNotFound, token, stringsToPath(tokens[:i+1])))
+			}
+
+			currentData = v
+
+		} else if data, ok := currentData.([]interface{}); ok {
+			index, ok := pointerIndex(token)
+			if !ok {
+				return nil, d.fail(stringsToPath(tokens), fmt.Errorf(errorExpectedIndex, token, token, stringsToPath(tokens[:i+1])))
+			}
+
+			if index >= len(data) {
+				return nil, d.fail(stringsToPath(tokens), fmt.Errorf(errorIndexOutOfRange, index, stringsToPath(tokens[:i+1])))
+			}
+
+			currentData = data[index]
+
+		} else {
+			return nil, d.fail(stringsToPath(tokens), fmt.Errorf(errorUnexpectedType, stringsToPath(tokens[:i])))
+		}
+	}
+
+	return d.wrap(currentData), nil
+}
+
+// SetByPointer sets value at a JSON pointer (RFC 6901). The data referred to by the pointer without its last token has to exist already, as no intermediate maps are created. In a map, the last token is the key to set, whether it exists or not. In a slice, it is the index of the element to replace, or "-" to append value after the last element. The empty pointer replaces the whole data.
+func (d *DMap) SetByPointer(pointer string, value interface{}) error {
+	if d.isReadOnly() {
+		return ErrReadOnly
+	}
+
+	tokens, err := ParsePointer(pointer)
+	if err != nil {
+		return err
+	}
+
+	if len(tokens) == 0 {
+		return d.Replace(value)
+	}
+
+	last := len(tokens) - 1
+	parent, err := d.GetByPointerTokens(tokens[:last])
+	if err != nil {
+		return err
+	}
+
+	path := pointerPath(d.Data(), tokens[:last])
+	token := tokens[last]
+
+	if isMap(parent.Data()) {
+		return d.setAt(value, appendPath(path, token))
+	}
+
+	if data, ok := parent.Data().([]interface{}); ok {
+		if token == "-" {
+			return d.setAt(append(data, value), path)
+		}
+
+		index, ok := pointerIndex(token)
+		if !ok {
+			return fmt.Errorf(errorExpectedIndex, token, token, stringsToPath(tokens))
+		}
+
+		return d.setAt(value, appendPath(path, index))
+	}
+
+	return fmt.Errorf(errorUnexpectedType, stringsToPath(tokens[:last]))
+}
+
+// DeleteByPointer deletes the data at a JSON pointer (RFC 6901), which has to exist. Deleting an element of a slice moves the elements after it back by one. The empty pointer cannot be deleted.
+func (d *DMap) DeleteByPointer(pointer string) error {
+	if d.isReadOnly() {
+		return ErrReadOnly
+	}
+
+	tokens, err := ParsePointer(pointer)
+	if err != nil {
+		return err
+	}
+
+	if len(tokens) == 0 {
+		return fmt.Errorf(errorDeleteRoot)
+	}
+
+	_, err = d.GetByPointerTokens(tokens)
+	if err != nil {
+		return err
+	}
+
+	return d.deleteAt(pointerPath(d.Data(), tokens))
+}
+
+// pointerPath converts reference tokens, which have to refer to existing data, to a path for Get.
+func pointerPath(v interface{}, tokens []string) []interface{} {
+	path := make([]interface{}, len(tokens))
+
+	for i, token := range tokens {
+		if data, ok := v.([]interface{}); ok {
+			index, _ := pointerIndex(token)
+			path[i] = index
+			v = data[index]
+		} else {
+			path[i] = token
+			v, _ = mapValue(v, token)
+		}
+	}
+
+	return path
+}
+
+// pointerIndex converts a reference token to an array index. The token has to be a decimal number without leading zeros.
+func pointerIndex(token string) (int, bool) {
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, false
+	}
+
+	for i := 0; i < len(token); i++ {
+		if token[i] < '0' || token[i] > '9' {
+			return 0, false
+		}
+	}
+
+	index, err := strconv.Atoi(token)
+	if err != nil {
+		return 0, false
+	}
+
+	return index, true
+}
@@ -0,0 +1,238 @@
+package dmap
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSetByPointer(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		pointer string
+		value   interface{}
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "existing key",
+			in:      `{"a":{"b":1}}`,
+			pointer: "/a/b",
+			value:   "x",
+			want:    `{"a":{"b":"x"}}`,
+		},
+		{
+			name:    "new key",
+			in:      `{"a":{}}`,
+			pointer: "/a/b",
+			value:   "x",
+			want:    `{"a":{"b":"x"}}`,
+		},
+		{
+			name:    "escaped slash",
+			in:      `{"a/b":1}`,
+			pointer: "/a~1b",
+			value:   "x",
+			want:    `{"a/b":"x"}`,
+		},
+		{
+			name:    "escaped tilde",
+			in:      `{"m~n":1}`,
+			pointer: "/m~0n",
+			value:   "x",
+			want:    `{"m~n":"x"}`,
+		},
+		{
+			name:    "escapes unescaped once",
+			in:      `{}`,
+			pointer: "/~01",
+			value:   "x",
+			want:    `{"~1":"x"}`,
+		},
+		{
+			name:    "index",
+			in:      `{"a":[1,2]}`,
+			pointer: "/a/1",
+			value:   "x",
+			want:    `{"a":[1,"x"]}`,
+		},
+		{
+			name:    "append",
+			in:      `{"a":[1,2]}`,
+			pointer: "/a/-",
+			value:   "x",
+			want:    `{"a":[1,2,"x"]}`,
+		},
+		{
+			name:    "root",
+			in:      `{"a":1}`,
+			pointer: "",
+			value:   "x",
+			want:    `"x"`,
+		},
+		{
+			name:    "index out of range",
+			in:      `{"a":[1,2]}`,
+			pointer: "/a/2",
+			value:   "x",
+			want:    `{"a":[1,2]}`,
+			wantErr: true,
+		},
+		{
+			name:    "leading zero index",
+			in:      `{"a":[1,2]}`,
+			pointer: "/a/01",
+			value:   "x",
+			want:    `{"a":[1,2]}`,
+			wantErr: true,
+		},
+		{
+			name:    "missing parent",
+			in:      `{}`,
+			pointer: "/a/b",
+			value:   "x",
+			want:    `{}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid escape",
+			in:      `{"a":1}`,
+			pointer: "/a~2",
+			value:   "x",
+			want:    `{"a":1}`,
+			wantErr: true,
+		},
+		{
+			name:    "no leading slash",
+			in:      `{"a":1}`,
+			pointer: "a",
+			value:   "x",
+			want:    `{"a":1}`,
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := MustParseJSONString(test.in)
+
+			err := d.SetByPointer(test.pointer, test.value)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got error %v, want error %v", err, test.wantErr)
+			}
+
+			want := MustParseJSONString(test.want).Data()
+			if !reflect.DeepEqual(d.Data(), want) {
+				t.Errorf("got %v, want %v", d.Data(), want)
+			}
+		})
+	}
+}
+
+func TestDeleteByPointer(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		pointer string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "key",
+			in:      `{"a":{"b":1,"c":2}}`,
+			pointer: "/a/b",
+			want:    `{"a":{"c":2}}`,
+		},
+		{
+			name:    "escaped slash",
+			in:      `{"a/b":1,"c":2}`,
+			pointer: "/a~1b",
+			want:    `{"c":2}`,
+		},
+		{
+			name:    "escaped tilde",
+			in:      `{"m~n":1,"c":2}`,
+			pointer: "/m~0n",
+			want:    `{"c":2}`,
+		},
+		{
+			name:    "element",
+			in:      `{"a":[1,2,3]}`,
+			pointer: "/a/1",
+			want:    `{"a":[1,3]}`,
+		},
+		{
+			name:    "missing key",
+			in:      `{"a":{}}`,
+			pointer: "/a/b",
+			want:    `{"a":{}}`,
+			wantErr: true,
+		},
+		{
+			name:    "append token",
+			in:      `{"a":[1]}`,
+			pointer: "/a/-",
+			want:    `{"a":[1]}`,
+			wantErr: true,
+		},
+		{
+			name:    "root",
+			in:      `{"a":1}`,
+			pointer: "",
+			want:    `{"a":1}`,
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := MustParseJSONString(test.in)
+
+			err := d.DeleteByPointer(test.pointer)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got error %v, want error %v", err, test.wantErr)
+			}
+
+			want := MustParseJSONString(test.want).Data()
+			if !reflect.DeepEqual(d.Data(), want) {
+				t.Errorf("got %v, want %v", d.Data(), want)
+			}
+		})
+	}
+}
+
+func TestPointerReadOnly(t *testing.T) {
+	frozen := MustParseJSONString(`{"a":[1]}`).Freeze()
+
+	if err := frozen.SetByPointer("/a/-", 2); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("SetByPointer: got error %v, want ErrReadOnly", err)
+	}
+	if err := frozen.DeleteByPointer("/a/0"); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("DeleteByPointer: got error %v, want ErrReadOnly", err)
+	}
+}
+
+func TestDeleteOnChange(t *testing.T) {
+	d := MustParseJSONString(`{"m":{"k":1},"s":["a","b","c"]}`)
+
+	var changes [][]interface{}
+	d.OnChange(func(path []interface{}, oldVal, newVal interface{}) {
+		changes = append(changes, []interface{}{path, oldVal, newVal})
+	})
+
+	if err := d.DeleteByPointer("/m/k"); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.DeleteByPointer("/s/1"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := [][]interface{}{
+		{[]interface{}{"m", "k"}, float64(1), nil},
+		{[]interface{}{"s", 1}, "b", nil},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("got changes %v, want %v", changes, want)
+	}
+}
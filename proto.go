@@ -0,0 +1,59 @@
+package dmap
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// protoJSONParser keeps the numbers of protojson messages as json.Number, so that the 64-bit integers written as numbers are not rounded.
+var protoJSONParser = &Parser{UseNumber: true}
+
+// ParseProtoJSON returns a new dmap with a message encoded with protojson unmarshalled, keeping every number as a json.Number.
+// The strings holding an integer, which protojson writes for 64-bit integers, become json.Number, and the padded base64 strings become []byte. Without a descriptor, string fields holding such values are converted as well.
+func ParseProtoJSON(b []byte) (*DMap, error) {
+	d, err := protoJSONParser.ParseBytes(b)
+	if err != nil {
+		return nil, err
+	}
+
+	d.data = normalizeProtoJSON(d.data)
+	return d, nil
+}
+
+// normalizeProtoJSON converts the strings of v written by protojson for 64-bit integers and bytes, modifying the maps and slices in place.
+func normalizeProtoJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, elem := range v {
+			v[key] = normalizeProtoJSON(elem)
+		}
+	case []interface{}:
+		for i, elem := range v {
+			v[i] = normalizeProtoJSON(elem)
+		}
+	case string:
+		if isProtoInteger(v) {
+			return json.Number(v)
+		}
+		if strings.HasSuffix(v, "=") && len(v)%4 == 0 {
+			b, err := base64.StdEncoding.DecodeString(v)
+			if err == nil {
+				return b
+			}
+		}
+	}
+	return v
+}
+
+// isProtoInteger checks if s is an int64 or a uint64 written in decimal the way protojson writes it, without a plus sign or leading zeros.
+func isProtoInteger(s string) bool {
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return strconv.FormatInt(i, 10) == s
+	}
+	if u, err := strconv.ParseUint(s, 10, 64); err == nil {
+		return strconv.FormatUint(u, 10) == s
+	}
+	return false
+}
@@ -0,0 +1,146 @@
+package dmap
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Count returns the number of values matched by a query expression. An expression is a path written like for GetByString, in which a "*" part matches every value of a map or every element of a slice, like "users.*.email". The matches are counted without being collected.
+func (d *DMap) Count(expr string) (int, error) {
+	if !d.HasData() {
+		return 0, fmt.Errorf(errorEmptyData)
+	}
+
+	return countQuery(d.Data(), splitQuery(expr)), nil
+}
+
+// Query returns the values matched by a query expression, written like for Count, in the order of Walk.
+func (d *DMap) Query(expr string) ([]*DMap, error) {
+	return d.QueryContext(context.Background(), expr)
+}
+
+// QueryContext is like Query, but stops and returns the error of the context once it is done. The context is checked before each value visited while matching the expression.
+func (d *DMap) QueryContext(ctx context.Context, expr string) ([]*DMap, error) {
+	if !d.HasData() {
+		return nil, fmt.Errorf(errorEmptyData)
+	}
+
+	var matches []*DMap
+	err := query(ctx, d.Data(), splitQuery(expr), nil, func(_ []interface{}, v interface{}) error {
+		matches = append(matches, d.wrap(v))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return matches, nil
+}
+
+// splitQuery splits a query expression into its parts.
+func splitQuery(expr string) []string {
+	if expr == "" {
+		return nil
+	}
+	return strings.Split(expr, ".")
+}
+
+// query calls fn with the path and the value of every match of parts in v, in the order of walk. If fn returns an error or the context is done, the query stops and the error is returned.
+func query(ctx context.Context, v interface{}, parts []string, path []interface{}, fn func(path []interface{}, v interface{}) error) error {
+	err := ctx.Err()
+	if err != nil {
+		return err
+	}
+
+	if len(parts) == 0 {
+		return fn(path, v)
+	}
+
+	part, rest := parts[0], parts[1:]
+
+	if isMap(v) {
+		if part != "*" {
+			value, ok := mapValue(v, part)
+			if !ok {
+				return nil
+			}
+			return query(ctx, value, rest, appendPath(path, part), fn)
+		}
+
+		for _, key := range sortedKeys(v) {
+			value, _ := mapValue(v, key)
+			err := query(ctx, value, rest, appendPath(path, key), fn)
+			if err != nil {
+				return err
+			}
+		}
+
+	} else if data, ok := v.([]interface{}); ok {
+		if part != "*" {
+			index, err := strconv.Atoi(part)
+			if err != nil || index < 0 || index >= len(data) {
+				return nil
+			}
+			return query(ctx, data[index], rest, appendPath(path, index), fn)
+		}
+
+		for i, elem := range data {
+			err := query(ctx, elem, rest, appendPath(path, i), fn)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// countQuery returns the number of matches of parts in v. Unlike query, it visits the maps in no particular order and keeps no path, so it does not allocate for string-keyed maps.
+func countQuery(v interface{}, parts []string) int {
+	if len(parts) == 0 {
+		return 1
+	}
+
+	part, rest := parts[0], parts[1:]
+	count := 0
+
+	if isMap(v) {
+		if part != "*" {
+			value, ok := mapValue(v, part)
+			if !ok {
+				return 0
+			}
+			return countQuery(value, rest)
+		}
+
+		// The values of string-keyed maps are ranged over directly, as eachMapEntry would allocate for every key it passes as an interface.
+		if m, ok := v.(map[string]interface{}); ok {
+			for _, value := range m {
+				count += countQuery(value, rest)
+			}
+			return count
+		}
+
+		eachMapEntry(v, func(_, value interface{}) bool {
+			count += countQuery(value, rest)
+			return true
+		})
+
+	} else if data, ok := v.([]interface{}); ok {
+		if part != "*" {
+			index, err := strconv.Atoi(part)
+			if err != nil || index < 0 || index >= len(data) {
+				return 0
+			}
+			return countQuery(data[index], rest)
+		}
+
+		for _, elem := range data {
+			count += countQuery(elem, rest)
+		}
+	}
+
+	return count
+}
@@ -0,0 +1,64 @@
+package dmap
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Render returns the template with every {path} token replaced by the data at that path, using the path syntax of GetByString. Strings are inserted as they are, nil as an empty string and any other data formatted with fmt.Sprint.
+// An error is returned if the path of a token does not exist.
+func (d *DMap) Render(template string) (string, error) {
+	return d.render(template, false)
+}
+
+// RenderLenient is like Render, but leaves the tokens whose path does not exist unchanged instead of returning an error.
+func (d *DMap) RenderLenient(template string) string {
+	s, _ := d.render(template, true)
+	return s
+}
+
+func (d *DMap) render(template string, lenient bool) (string, error) {
+	var b strings.Builder
+
+	for {
+		start := strings.IndexByte(template, '{')
+		if start < 0 {
+			break
+		}
+
+		end := strings.IndexByte(template[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+
+		b.WriteString(template[:start])
+
+		data, err := d.GetByString(template[start+1 : end])
+		if err != nil {
+			if !lenient {
+				return "", err
+			}
+			b.WriteString(template[start : end+1])
+		} else {
+			b.WriteString(stringify(data.Data()))
+		}
+
+		template = template[end+1:]
+	}
+
+	b.WriteString(template)
+
+	return b.String(), nil
+}
+
+// stringify formats v for display. Strings are returned as they are and nil as an empty string.
+func stringify(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,71 @@
+package dmap
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// PathErrorKind tells why a path failed, in a PathError.
+type PathErrorKind int
+
+const (
+	// PathMissing means that there is no data at the path.
+	PathMissing PathErrorKind = iota + 1
+	// PathNull means that the data at the path is nil.
+	PathNull
+)
+
+// String returns a short description of the kind.
+func (k PathErrorKind) String() string {
+	switch k {
+	case PathMissing:
+		return "missing"
+	case PathNull:
+		return "null"
+	}
+	return fmt.Sprintf("PathErrorKind(%d)", int(k))
+}
+
+// PathError reports a path which does not hold the data it should, along with the kind of the failure. It is returned by Require, and can be taken from its error with errors.As.
+type PathError struct {
+	Path []interface{}
+	Kind PathErrorKind
+}
+
+// Error returns a message meant for the users who wrote the data, with the path written with dots like in GetByString, such as "items.0.id is required".
+func (e *PathError) Error() string {
+	name := formatPath(e.Path)
+	if e.Kind == PathNull {
+		return name + " must not be null"
+	}
+	return name + " is required"
+}
+
+// Require checks that there is data which is not nil at every one of the given paths, like the required fields of a request. It returns nil if they all have data, and otherwise the PathError of every failing path joined with errors.Join, in the order the paths were passed, so its message lists one path per line.
+func (d *DMap) Require(paths ...[]interface{}) error {
+	var errs []error
+	for _, path := range paths {
+		data, ok := d.Lookup(path...)
+		if !ok {
+			errs = append(errs, &PathError{Path: path, Kind: PathMissing})
+		} else if data.Data() == nil {
+			errs = append(errs, &PathError{Path: path, Kind: PathNull})
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// formatPath writes a path with its parts separated by dots, and the empty path as "data".
+func formatPath(path []interface{}) string {
+	if len(path) == 0 {
+		return "data"
+	}
+
+	parts := make([]string, len(path))
+	for i, p := range path {
+		parts[i] = fmt.Sprint(p)
+	}
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,131 @@
+package dmap
+
+import (
+	"fmt"
+)
+
+// SliceContains checks whether any element of the []interface{} at a given path is equal to value. Numbers are compared regardless of their types and maps regardless of their key types.
+func (d *DMap) SliceContains(value interface{}, path ...interface{}) (bool, error) {
+	data, err := d.GetSliceI(path...)
+	if err != nil {
+		return false, err
+	}
+
+	return sliceContains(data, value), nil
+}
+
+// IndexOf returns the index of the first element of the []interface{} at a given path which is equal to value, or -1 if there is none. Values are compared like in SliceContains.
+func (d *DMap) IndexOf(value interface{}, path ...interface{}) (int, error) {
+	data, err := d.GetSliceI(path...)
+	if err != nil {
+		return -1, err
+	}
+
+	for i, elem := range data {
+		if equal(elem, value) {
+			return i, nil
+		}
+	}
+
+	return -1, nil
+}
+
+// MapHasValue checks whether any value of the map at a given path is equal to value. Values are compared like in SliceContains.
+func (d *DMap) MapHasValue(value interface{}, path ...interface{}) (bool, error) {
+	data, err := d.Get(path...)
+	if err != nil {
+		return false, err
+	}
+
+	if !isMap(data.Data()) {
+		return false, fmt.Errorf(errorNotMap, path)
+	}
+
+	found := false
+	eachMapEntry(data.Data(), func(_, v interface{}) bool {
+		found = equal(v, value)
+		return !found
+	})
+
+	return found, nil
+}
+
+// FilterSlice returns the elements of the []interface{} at a given path for which pred returns true, in order.
+func (d *DMap) FilterSlice(pred func(elem *DMap) bool, path ...interface{}) ([]*DMap, error) {
+	elems, err := d.GetDMapSlice(path...)
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []*DMap
+	for _, elem := range elems {
+		if pred(elem) {
+			matched = append(matched, elem)
+		}
+	}
+
+	return matched, nil
+}
+
+// Partition splits the elements of the []interface{} at a given path into the ones for which pred returns true and the others, calling pred once for every element and keeping the order.
+func (d *DMap) Partition(pred func(elem *DMap) bool, path ...interface{}) (matched []*DMap, rest []*DMap, err error) {
+	elems, err := d.GetDMapSlice(path...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, elem := range elems {
+		if pred(elem) {
+			matched = append(matched, elem)
+		} else {
+			rest = append(rest, elem)
+		}
+	}
+
+	return matched, rest, nil
+}
+
+// FilterMap returns the entries of the map at a given path for which pred returns true.
+func (d *DMap) FilterMap(pred func(key interface{}, value *DMap) bool, path ...interface{}) (map[interface{}]*DMap, error) {
+	data, err := d.Get(path...)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isMap(data.Data()) {
+		return nil, fmt.Errorf(errorNotMap, path)
+	}
+
+	matched := map[interface{}]*DMap{}
+	eachMapEntry(data.Data(), func(k, v interface{}) bool {
+		value := d.wrap(v)
+		if pred(k, value) {
+			matched[k] = value
+		}
+		return true
+	})
+
+	return matched, nil
+}
+
+// FindValue returns the first entry of the map at a given path for which pred returns true, stopping there. The entries are visited in the order of Walk. If no entry matches, the returned dmap is nil and so is the error.
+func (d *DMap) FindValue(pred func(key interface{}, value *DMap) bool, path ...interface{}) (interface{}, *DMap, error) {
+	data, err := d.Get(path...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !isMap(data.Data()) {
+		return nil, nil, fmt.Errorf(errorNotMap, path)
+	}
+
+	for _, key := range sortedKeys(data.Data()) {
+		v, _ := mapValue(data.Data(), key)
+		value := d.wrap(v)
+		if pred(key, value) {
+			return key, value, nil
+		}
+	}
+
+	return nil, nil, nil
+}
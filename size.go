@@ -0,0 +1,65 @@
+package dmap
+
+import (
+	"encoding/json"
+)
+
+// The sizes in bytes used by ApproxSize, for a 64-bit platform.
+const (
+	sizeInterface = 16
+	sizeString    = 16
+	sizeSlice     = 24
+	sizeMap       = 48
+	sizeMapEntry  = 8
+	sizeScalar    = 8
+)
+
+// ApproxSize returns an estimate of the memory used by the data of the dmap in bytes, for budgeting caches of parsed documents. It is computed in a single traversal, without sorting or allocating.
+// Every value counts as an interface of 16 bytes plus its content: 16 bytes and the length for a string or a json.Number, 8 bytes for any other scalar, 24 bytes and the elements for a slice, and 48 bytes for a map with its keys, values and 8 bytes of overhead per entry. The estimate ignores the memory shared between values and grows with the data, but it is not the exact size allocated by the runtime.
+func (d *DMap) ApproxSize() int {
+	return approxSize(d.Data())
+}
+
+func approxSize(v interface{}) int {
+	size := sizeInterface
+
+	switch v := v.(type) {
+	case nil:
+
+	case string:
+		size += sizeString + len(v)
+
+	case json.Number:
+		size += sizeString + len(v)
+
+	case []interface{}:
+		size += sizeSlice
+		for _, elem := range v {
+			size += approxSize(elem)
+		}
+
+	case map[string]interface{}:
+		size += sizeMap
+		for k, elem := range v {
+			size += sizeMapEntry + sizeString + len(k) + approxSize(elem)
+		}
+
+	case map[interface{}]interface{}:
+		size += sizeMap
+		for k, elem := range v {
+			size += sizeMapEntry + approxSize(k) + approxSize(elem)
+		}
+
+	case *OrderedMap:
+		// The keys are stored both in the map and in the slice of keys.
+		size += sizeMap + sizeSlice
+		for _, k := range v.keys {
+			size += sizeMapEntry + 2*sizeString + len(k) + approxSize(v.values[k])
+		}
+
+	default:
+		size += sizeScalar
+	}
+
+	return size
+}
@@ -0,0 +1,101 @@
+package dmap
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+var (
+	errorInvalidRelativeTime = "invalid relative time %q at %v"
+)
+
+// relativeUnits are the units of the offsets in relative times, from the longest to the shortest name sharing a prefix.
+var relativeUnits = []struct {
+	name     string
+	duration time.Duration
+}{
+	{"ms", time.Millisecond},
+	{"s", time.Second},
+	{"m", time.Minute},
+	{"h", time.Hour},
+	{"d", 24 * time.Hour},
+	{"w", 7 * 24 * time.Hour},
+}
+
+// GetRelativeTime returns the string at a given path parsed as a time relative to base, like in the time ranges of queries and alerts. The string is "now", which is base itself, or an optional "now" followed by one or more offsets added to base. An offset is a + or a - followed by one or more integers with a unit, where the units are ms, s, m, h, d for 24 hours and w for 7 days, so "now-7d", "-24h", "+30m" and "now-1d12h+5m" are all accepted. Days and weeks are fixed durations, and do not follow the calendar or daylight saving time. Spaces are not allowed.
+func (d *DMap) GetRelativeTime(base time.Time, path ...interface{}) (time.Time, error) {
+	s, err := d.GetString(path...)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	offset, ok := parseRelativeTime(s)
+	if !ok {
+		return time.Time{}, fmt.Errorf(errorInvalidRelativeTime, s, path)
+	}
+
+	return base.Add(offset), nil
+}
+
+// parseRelativeTime returns the sum of the offsets of a relative time. It returns false if s is not a relative time or if the sum overflows.
+func parseRelativeTime(s string) (time.Duration, bool) {
+	rest := strings.TrimPrefix(s, "now")
+	if rest == "" {
+		return 0, s == "now"
+	}
+
+	var total time.Duration
+	for rest != "" {
+		sign := time.Duration(1)
+		switch rest[0] {
+		case '+':
+		case '-':
+			sign = -1
+		default:
+			return 0, false
+		}
+		rest = rest[1:]
+
+		var offset time.Duration
+		terms := 0
+		for rest != "" && rest[0] >= '0' && rest[0] <= '9' {
+			n := time.Duration(0)
+			for rest != "" && rest[0] >= '0' && rest[0] <= '9' {
+				n = n*10 + time.Duration(rest[0]-'0')
+				if n > 1<<32 {
+					return 0, false
+				}
+				rest = rest[1:]
+			}
+
+			found := false
+			for _, unit := range relativeUnits {
+				if strings.HasPrefix(rest, unit.name) {
+					if n > (math.MaxInt64-offset)/unit.duration {
+						return 0, false
+					}
+					offset += n * unit.duration
+					rest = rest[len(unit.name):]
+					found = true
+					break
+				}
+			}
+			if !found {
+				return 0, false
+			}
+			terms++
+		}
+		if terms == 0 {
+			return 0, false
+		}
+
+		if (sign > 0 && total > math.MaxInt64-offset) || (sign < 0 && total < math.MinInt64+offset) {
+			return 0, false
+		}
+		total += sign * offset
+	}
+
+	return total, true
+}
@@ -0,0 +1,90 @@
+package dmap
+
+import (
+	"fmt"
+)
+
+var (
+	errorUnexpectedNodeType = "expected %v at %v, got %v"
+)
+
+// NodeType is the kind of data held by a dmap, as returned by Type.
+type NodeType int
+
+const (
+	// TypeNull is the type of nil.
+	TypeNull NodeType = iota
+	// TypeBool is the type of bool values.
+	TypeBool
+	// TypeNumber is the type of any Go numeric value and of json.Number.
+	TypeNumber
+	// TypeString is the type of string values.
+	TypeString
+	// TypeMap is the type of map[string]interface{} and map[interface{}]interface{}.
+	TypeMap
+	// TypeSlice is the type of []interface{}.
+	TypeSlice
+	// TypeOther is the type of any other value.
+	TypeOther
+)
+
+var nodeTypeNames = map[NodeType]string{
+	TypeNull:   "null",
+	TypeBool:   "bool",
+	TypeNumber: "number",
+	TypeString: "string",
+	TypeMap:    "map",
+	TypeSlice:  "slice",
+	TypeOther:  "other",
+}
+
+// String returns the name of the node type.
+func (t NodeType) String() string {
+	if name, ok := nodeTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("NodeType(%d)", int(t))
+}
+
+// Type returns the type of the data stored by the dmap. It returns TypeNull for a nil dmap.
+func (d *DMap) Type() NodeType {
+	return typeOf(d.Data())
+}
+
+// GetTyped returns the data at a given path, and an error if its type is not the expected one.
+func (d *DMap) GetTyped(expected NodeType, path ...interface{}) (*DMap, error) {
+	data, err := d.Get(path...)
+	if err != nil {
+		return nil, err
+	}
+
+	if t := data.Type(); t != expected {
+		return nil, fmt.Errorf(errorUnexpectedNodeType, expected, path, t)
+	}
+
+	return data, nil
+}
+
+// typeOf returns the node type of v.
+func typeOf(v interface{}) NodeType {
+	switch v.(type) {
+	case nil:
+		return TypeNull
+	case bool:
+		return TypeBool
+	case string:
+		return TypeString
+	case []interface{}:
+		return TypeSlice
+	}
+
+	if isMap(v) {
+		return TypeMap
+	}
+
+	if isNumber(v) {
+		return TypeNumber
+	}
+
+	return TypeOther
+}
@@ -0,0 +1,107 @@
+package dmap
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// FromURLValues returns a new dmap with a map[string]interface{} built from form or query values. A key with a single value maps to that string and a key with several values to a []interface{} of them.
+// Keys written in bracket notation build nested maps, so a[b][c]=1 sets "1" at the path a, b, c. A trailing [] is dropped, so a[]=1&a[]=2 maps a to both values, and a key which is not well-formed is used as it is. If a key is both a value and a map, like in a=1&a[b]=2, the map is kept.
+func FromURLValues(values url.Values) *DMap {
+	return fromURLValues(values, true)
+}
+
+// FromURLValuesFlat is like FromURLValues, but uses every key as it is, without building nested maps from bracket notation.
+func FromURLValuesFlat(values url.Values) *DMap {
+	return fromURLValues(values, false)
+}
+
+func fromURLValues(values url.Values, nested bool) *DMap {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	// A key always sorts before the keys nesting under it, so the maps replace the values they conflict with.
+	sort.Strings(keys)
+
+	root := map[string]interface{}{}
+	for _, key := range keys {
+		path := []string{key}
+		if nested {
+			path = splitURLKey(key)
+		}
+
+		m := root
+		for _, p := range path[:len(path)-1] {
+			next, ok := m[p].(map[string]interface{})
+			if !ok {
+				next = map[string]interface{}{}
+				m[p] = next
+			}
+			m = next
+		}
+
+		last := path[len(path)-1]
+		switch existing := m[last].(type) {
+		case map[string]interface{}:
+		case []string:
+			m[last] = append(existing, values[key]...)
+		default:
+			m[last] = append([]string(nil), values[key]...)
+		}
+	}
+
+	return &DMap{data: urlLeaves(root)}
+}
+
+// splitURLKey splits a key in bracket notation, like a[b][c], into its parts. A trailing [] is dropped, and a key which is not well-formed is returned as a single part.
+func splitURLKey(key string) []string {
+	trimmed := strings.TrimSuffix(key, "[]")
+
+	i := strings.IndexByte(trimmed, '[')
+	if i == 0 || trimmed == "" {
+		return []string{key}
+	}
+	if i < 0 {
+		return []string{trimmed}
+	}
+
+	parts := []string{trimmed[:i]}
+	rest := trimmed[i:]
+	for rest != "" {
+		end := strings.IndexByte(rest, ']')
+		if rest[0] != '[' || end < 2 {
+			return []string{key}
+		}
+
+		parts = append(parts, rest[1:end])
+		rest = rest[end+1:]
+	}
+
+	return parts
+}
+
+// urlLeaves replaces the values collected by fromURLValues with a string or a []interface{} of strings.
+func urlLeaves(m map[string]interface{}) map[string]interface{} {
+	for k, v := range m {
+		switch v := v.(type) {
+		case map[string]interface{}:
+			urlLeaves(v)
+
+		case []string:
+			if len(v) == 1 {
+				m[k] = v[0]
+				continue
+			}
+
+			s := make([]interface{}, len(v))
+			for i, value := range v {
+				s[i] = value
+			}
+			m[k] = s
+		}
+	}
+
+	return m
+}
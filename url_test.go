@@ -0,0 +1,114 @@
+package dmap
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestFromURLValues(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+		flat  string
+	}{
+		{
+			name:  "single value",
+			query: "a=1",
+			want:  `{"a":"1"}`,
+			flat:  `{"a":"1"}`,
+		},
+		{
+			name:  "empty value",
+			query: "a=",
+			want:  `{"a":""}`,
+			flat:  `{"a":""}`,
+		},
+		{
+			name:  "repeated key",
+			query: "a=1&a=2",
+			want:  `{"a":["1","2"]}`,
+			flat:  `{"a":["1","2"]}`,
+		},
+		{
+			name:  "repeated key with brackets",
+			query: "a[]=1&a[]=2",
+			want:  `{"a":["1","2"]}`,
+			flat:  `{"a[]":["1","2"]}`,
+		},
+		{
+			name:  "single key with brackets",
+			query: "a[]=1",
+			want:  `{"a":"1"}`,
+			flat:  `{"a[]":"1"}`,
+		},
+		{
+			name:  "key with and without brackets",
+			query: "a=1&a[]=2",
+			want:  `{"a":["1","2"]}`,
+			flat:  `{"a":"1","a[]":"2"}`,
+		},
+		{
+			name:  "nested keys",
+			query: "a[b][c]=1&a[b][d]=2",
+			want:  `{"a":{"b":{"c":"1","d":"2"}}}`,
+			flat:  `{"a[b][c]":"1","a[b][d]":"2"}`,
+		},
+		{
+			name:  "repeated nested key",
+			query: "a[b]=1&a[b]=2",
+			want:  `{"a":{"b":["1","2"]}}`,
+			flat:  `{"a[b]":["1","2"]}`,
+		},
+		{
+			name:  "value and map",
+			query: "a=1&a[b]=2",
+			want:  `{"a":{"b":"2"}}`,
+			flat:  `{"a":"1","a[b]":"2"}`,
+		},
+		{
+			name:  "unclosed bracket",
+			query: "a[b=1",
+			want:  `{"a[b":"1"}`,
+			flat:  `{"a[b":"1"}`,
+		},
+		{
+			name:  "leading bracket",
+			query: "[a]=1",
+			want:  `{"[a]":"1"}`,
+			flat:  `{"[a]":"1"}`,
+		},
+		{
+			name:  "text after brackets",
+			query: "a[]b=1",
+			want:  `{"a[]b":"1"}`,
+			flat:  `{"a[]b":"1"}`,
+		},
+		{
+			name:  "no values",
+			query: "",
+			want:  `{}`,
+			flat:  `{}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			values, err := url.ParseQuery(test.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := MustParseJSONString(test.want).Data()
+			if got := FromURLValues(values).Data(); !reflect.DeepEqual(got, want) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+
+			flat := MustParseJSONString(test.flat).Data()
+			if got := FromURLValuesFlat(values).Data(); !reflect.DeepEqual(got, flat) {
+				t.Errorf("flat: got %v, want %v", got, flat)
+			}
+		})
+	}
+}
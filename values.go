@@ -0,0 +1,245 @@
+package dmap
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	errorInvalidRegexp = "invalid regexp at %v: %w"
+	errorInvalidIP     = "invalid IP address %q at %v"
+	errorInvalidIPNet  = "invalid CIDR address at %v: %w"
+	errorNotBool       = "data at %v is not a bool"
+	errorInvalidBool   = "value %v at %v is not a recognized bool"
+	errorNotScalar     = "data at %v is not a scalar"
+	errorInvalidBase64 = "invalid base64 at %v: %w"
+)
+
+// GetString returns the data at a given path as string.
+func (d *DMap) GetString(path ...interface{}) (string, error) {
+	data, err := d.Get(path...)
+	if err != nil {
+		return "", err
+	}
+
+	s, ok := data.Data().(string)
+	if !ok {
+		return "", fmt.Errorf(errorNotString, path)
+	}
+
+	return s, nil
+}
+
+// GetStringTrimmed returns the string at a given path without its leading and trailing white space, as in strings.TrimSpace.
+func (d *DMap) GetStringTrimmed(path ...interface{}) (string, error) {
+	s, err := d.GetString(path...)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(s), nil
+}
+
+// GetStringNormalized returns the string at a given path with its leading and trailing white space removed and every other run of white space replaced by a single space, as in strings.Fields.
+func (d *DMap) GetStringNormalized(path ...interface{}) (string, error) {
+	s, err := d.GetString(path...)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Join(strings.Fields(s), " "), nil
+}
+
+// GetStringSliceCoerce returns the []interface{} at a given path as []string, formatting the elements which are not strings like Render does, so ["a", 1, true] becomes ["a", "1", "true"] and nil becomes "". An element which is a map or a slice returns an error.
+func (d *DMap) GetStringSliceCoerce(path ...interface{}) ([]string, error) {
+	data, err := d.GetSliceI(path...)
+	if err != nil {
+		return nil, err
+	}
+
+	strs := make([]string, len(data))
+	for i, elem := range data {
+		if isMap(elem) || isSlice(elem) {
+			return nil, fmt.Errorf(errorNotScalar, appendPath(path, i))
+		}
+		strs[i] = stringify(elem)
+	}
+
+	return strs, nil
+}
+
+// GetBytes returns the string at a given path decoded from base64, as in the bytes fields of protojson. Both the standard and the URL-safe alphabets are accepted, with or without padding, and a []byte is returned as it is.
+func (d *DMap) GetBytes(path ...interface{}) ([]byte, error) {
+	data, err := d.Get(path...)
+	if err != nil {
+		return nil, err
+	}
+
+	if b, ok := data.Data().([]byte); ok {
+		return b, nil
+	}
+
+	s, ok := data.Data().(string)
+	if !ok {
+		return nil, fmt.Errorf(errorNotString, path)
+	}
+
+	encoding := base64.RawStdEncoding
+	if strings.ContainsAny(s, "-_") {
+		encoding = base64.RawURLEncoding
+	}
+
+	b, err := encoding.DecodeString(strings.TrimRight(s, "="))
+	if err != nil {
+		return nil, fmt.Errorf(errorInvalidBase64, path, err)
+	}
+
+	return b, nil
+}
+
+// GetBool returns the data at a given path as bool.
+func (d *DMap) GetBool(path ...interface{}) (bool, error) {
+	data, err := d.Get(path...)
+	if err != nil {
+		return false, err
+	}
+
+	b, ok := data.Data().(bool)
+	if !ok {
+		return false, fmt.Errorf(errorNotBool, path)
+	}
+
+	return b, nil
+}
+
+// GetBoolLoose returns the data at a given path as bool, also accepting strings and numbers. Strings are parsed with strconv.ParseBool, and "yes", "no", "on" and "off" are accepted in any case. Numbers have to be 1 or 0.
+func (d *DMap) GetBoolLoose(path ...interface{}) (bool, error) {
+	data, err := d.Get(path...)
+	if err != nil {
+		return false, err
+	}
+
+	var s string
+	switch v := data.Data().(type) {
+	case bool:
+		return v, nil
+	case string:
+		s = v
+	default:
+		if !isNumber(v) {
+			return false, fmt.Errorf(errorNotBool, path)
+		}
+		s = fmt.Sprint(v)
+	}
+
+	switch strings.ToLower(s) {
+	case "yes", "on":
+		return true, nil
+	case "no", "off":
+		return false, nil
+	}
+
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return false, fmt.Errorf(errorInvalidBool, data.Data(), path)
+	}
+
+	return b, nil
+}
+
+// GetRegexp returns the string at a given path compiled with regexp.Compile.
+func (d *DMap) GetRegexp(path ...interface{}) (*regexp.Regexp, error) {
+	s, err := d.GetString(path...)
+	if err != nil {
+		return nil, err
+	}
+
+	re, err := regexp.Compile(s)
+	if err != nil {
+		return nil, fmt.Errorf(errorInvalidRegexp, path, err)
+	}
+
+	return re, nil
+}
+
+// GetIP returns the string at a given path parsed with net.ParseIP.
+func (d *DMap) GetIP(path ...interface{}) (net.IP, error) {
+	s, err := d.GetString(path...)
+	if err != nil {
+		return nil, err
+	}
+
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return nil, fmt.Errorf(errorInvalidIP, s, path)
+	}
+
+	return ip, nil
+}
+
+// GetIPNet returns the string at a given path parsed with net.ParseCIDR.
+func (d *DMap) GetIPNet(path ...interface{}) (*net.IPNet, error) {
+	s, err := d.GetString(path...)
+	if err != nil {
+		return nil, err
+	}
+
+	_, ipNet, err := net.ParseCIDR(s)
+	if err != nil {
+		return nil, fmt.Errorf(errorInvalidIPNet, path, err)
+	}
+
+	return ipNet, nil
+}
+
+// isNumber checks if v has one of the Go numeric types or is a json.Number.
+func isNumber(v interface{}) bool {
+	switch v.(type) {
+	case float64, float32, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, json.Number:
+		return true
+	}
+	return false
+}
+
+// toFloat64 converts a number to float64. It returns false if v is not a number.
+func toFloat64(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil && !math.IsInf(f, 0) {
+			return 0, false
+		}
+		return f, true
+	}
+	return 0, false
+}
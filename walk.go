@@ -0,0 +1,148 @@
+package dmap
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// GetAllByKey returns every value stored under key in any map of the data, along with its path. The data is traversed depth first, visiting a map before its values, the keys of a map in sorted order and the elements of a slice in order. The values of a map are returned when the map is visited, so a match is returned before the matches nested in it or in the other values of its map.
+func (d *DMap) GetAllByKey(key interface{}) ([]*DMap, [][]interface{}, error) {
+	if !d.HasData() {
+		return nil, nil, fmt.Errorf(errorEmptyData)
+	}
+
+	var values []*DMap
+	var paths [][]interface{}
+
+	walk(d.Data(), nil, func(path []interface{}, v interface{}) error {
+		if value, ok := mapValue(v, key); ok {
+			values = append(values, d.wrap(value))
+			paths = append(paths, appendPath(path, key))
+		}
+		return nil
+	})
+
+	return values, paths, nil
+}
+
+// Walk calls fn with the path and the value of every value in the data, starting with the whole data. The data is traversed depth first, visiting a map or a slice before its contents, the keys of a map in sorted order, or in their own order for an *OrderedMap, and the elements of a slice in order. If fn returns an error, the walk stops and the error is returned.
+func (d *DMap) Walk(fn func(path []interface{}, value *DMap) error) error {
+	return d.WalkContext(context.Background(), fn)
+}
+
+// WalkContext is like Walk, but stops and returns the error of the context once it is done. The context is checked before each value is visited.
+func (d *DMap) WalkContext(ctx context.Context, fn func(path []interface{}, value *DMap) error) error {
+	return walk(d.Data(), nil, func(path []interface{}, v interface{}) error {
+		err := ctx.Err()
+		if err != nil {
+			return err
+		}
+		return fn(path, d.wrap(v))
+	})
+}
+
+// WalkLeaves is like Walk, but only calls fn for the values which are not maps or slices, passing them as they are.
+func (d *DMap) WalkLeaves(fn func(path []interface{}, value interface{}) error) error {
+	return walk(d.Data(), nil, func(path []interface{}, v interface{}) error {
+		if isMap(v) || isSlice(v) {
+			return nil
+		}
+		return fn(path, v)
+	})
+}
+
+// WalkContainers is like Walk, but only calls fn for the maps and slices, including the empty ones, passing them as they are.
+func (d *DMap) WalkContainers(fn func(path []interface{}, value interface{}) error) error {
+	return walk(d.Data(), nil, func(path []interface{}, v interface{}) error {
+		if !isMap(v) && !isSlice(v) {
+			return nil
+		}
+		return fn(path, v)
+	})
+}
+
+// walk calls fn for v and then for everything inside it, depth first. The keys of a map are visited in sorted order. If fn returns an error the traversal stops and the error is returned.
+func walk(v interface{}, path []interface{}, fn func(path []interface{}, v interface{}) error) error {
+	err := fn(path, v)
+	if err != nil {
+		return err
+	}
+
+	switch v := v.(type) {
+	case map[string]interface{}, map[interface{}]interface{}, *OrderedMap:
+		for _, key := range sortedKeys(v) {
+			value, _ := mapValue(v, key)
+			err = walk(value, appendPath(path, key), fn)
+			if err != nil {
+				return err
+			}
+		}
+
+	case []interface{}:
+		for i, elem := range v {
+			err = walk(elem, appendPath(path, i), fn)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// sortedKeys returns the keys of the map m in sorted order. Keys which are not strings are sorted by their fmt.Sprint representation and then by their type. The keys of an *OrderedMap are returned in their own order.
+func sortedKeys(m interface{}) []interface{} {
+	if m, ok := m.(*OrderedMap); ok {
+		keys := make([]interface{}, m.Len())
+		for i, key := range m.keys {
+			keys[i] = key
+		}
+		return keys
+	}
+
+	if m, ok := m.(map[string]interface{}); ok {
+		names := make([]string, 0, len(m))
+		for key := range m {
+			names = append(names, key)
+		}
+		sort.Strings(names)
+
+		keys := make([]interface{}, len(names))
+		for i, name := range names {
+			keys[i] = name
+		}
+		return keys
+	}
+
+	// The representations of a key are computed once rather than in every comparison of the sort.
+	type sortKey struct {
+		key       interface{}
+		name, typ string
+	}
+
+	sorted := make([]sortKey, 0, mapLen(m))
+	eachMapEntry(m, func(key, _ interface{}) bool {
+		name, ok := key.(string)
+		typ := "string"
+		if !ok {
+			name, typ = fmt.Sprint(key), fmt.Sprintf("%T", key)
+		}
+		sorted = append(sorted, sortKey{key: key, name: name, typ: typ})
+		return true
+	})
+
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].name != sorted[j].name {
+			return sorted[i].name < sorted[j].name
+		}
+		return sorted[i].typ < sorted[j].typ
+	})
+
+	keys := make([]interface{}, len(sorted))
+	for i, k := range sorted {
+		keys[i] = k.key
+	}
+
+	return keys
+}
@@ -0,0 +1,223 @@
+package dmap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+)
+
+var (
+	errorYAMLIndent = "invalid YAML indentation %v, it has to be between 2 and 9"
+	errorYAMLValue  = "cannot encode data at %v as YAML: %w"
+	errorYAMLDecode = "cannot decode YAML, only encoding is supported"
+)
+
+// YAMLCodec is a Codec for YAML. It encodes like ToYAMLBytes, and its Decode always returns an error as the package has no YAML parser.
+var YAMLCodec Codec = yamlCodec{}
+
+type yamlCodec struct{}
+
+func (yamlCodec) Decode(b []byte) (interface{}, error) {
+	return nil, fmt.Errorf(errorYAMLDecode)
+}
+
+func (yamlCodec) Encode(v interface{}) ([]byte, error) {
+	return (&DMap{data: v}).ToYAMLBytes()
+}
+
+// ToYAMLBytes returns the data of the dmap encoded as YAML, indented by 2 spaces. See ToYAMLBytesIndent.
+func (d *DMap) ToYAMLBytes() ([]byte, error) {
+	return d.ToYAMLBytesIndent(2)
+}
+
+// ToYAMLBytesIndent returns the data of the dmap encoded as a YAML block document, with every level indented by a number of spaces from 2 to 9.
+func (d *DMap) ToYAMLBytesIndent(indent int) ([]byte, error) {
+	if indent < 2 || indent > 9 {
+		return nil, fmt.Errorf(errorYAMLIndent, indent)
+	}
+
+	var b bytes.Buffer
+	err := writeYAML(&b, d.Data(), nil, 0, indent, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// writeYAML writes v as a block node whose lines start at column col, except the first line if first is true, whose indentation is already written. The path of v is used for errors.
+func writeYAML(b *bytes.Buffer, v interface{}, path []interface{}, col, indent int, first bool) error {
+	if isMap(v) && mapLen(v) > 0 {
+		if m, ok := v.(map[interface{}]interface{}); ok {
+			_, err := mapIItoSI(m, path)
+			if err != nil {
+				return err
+			}
+		}
+
+		for i, key := range sortedKeys(v) {
+			if i > 0 || !first {
+				b.WriteString(strings.Repeat(" ", col))
+			}
+			b.WriteString(yamlString(fmt.Sprint(key)))
+			b.WriteByte(':')
+
+			elem, _ := mapValue(v, key)
+			if (isMap(elem) && mapLen(elem) > 0) || (isSlice(elem) && len(elem.([]interface{})) > 0) {
+				b.WriteByte('\n')
+				err := writeYAML(b, elem, appendPath(path, key), col+indent, indent, false)
+				if err != nil {
+					return err
+				}
+				continue
+			}
+
+			b.WriteByte(' ')
+			err := writeYAMLScalar(b, elem, appendPath(path, key))
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if s, ok := v.([]interface{}); ok && len(s) > 0 {
+		for i, elem := range s {
+			if i > 0 || !first {
+				b.WriteString(strings.Repeat(" ", col))
+			}
+			b.WriteByte('-')
+			b.WriteString(strings.Repeat(" ", indent-1))
+
+			if (isMap(elem) && mapLen(elem) > 0) || (isSlice(elem) && len(elem.([]interface{})) > 0) {
+				err := writeYAML(b, elem, appendPath(path, i), col+indent, indent, true)
+				if err != nil {
+					return err
+				}
+				continue
+			}
+
+			err := writeYAMLScalar(b, elem, appendPath(path, i))
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if !first {
+		b.WriteString(strings.Repeat(" ", col))
+	}
+	return writeYAMLScalar(b, v, path)
+}
+
+// writeYAMLScalar writes v, which is a scalar or an empty map or slice, followed by a line break.
+func writeYAMLScalar(b *bytes.Buffer, v interface{}, path []interface{}) error {
+	switch v := v.(type) {
+	case nil:
+		b.WriteString("null")
+	case string:
+		b.WriteString(yamlString(v))
+	case json.Number:
+		b.WriteString(yamlNumber(string(v)))
+	default:
+		if isMap(v) {
+			b.WriteString("{}")
+			break
+		}
+		if isSlice(v) {
+			b.WriteString("[]")
+			break
+		}
+
+		if f, ok := toFloat64(v); ok && (math.IsNaN(f) || math.IsInf(f, 0)) {
+			switch {
+			case math.IsNaN(f):
+				b.WriteString(".nan")
+			case f > 0:
+				b.WriteString(".inf")
+			default:
+				b.WriteString("-.inf")
+			}
+			break
+		}
+
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf(errorYAMLValue, path, err)
+		}
+
+		if isNumber(v) {
+			b.WriteString(yamlNumber(string(encoded)))
+		} else {
+			b.Write(encoded)
+		}
+	}
+
+	b.WriteByte('\n')
+	return nil
+}
+
+// yamlNumber returns the JSON number s written so that YAML 1.1 reads it back as a number as well, which requires a . in the mantissa and a sign in the exponent of the numbers with an exponent, like 1.0e+21 for 1e21.
+func yamlNumber(s string) string {
+	e := strings.IndexAny(s, "eE")
+	if e < 0 {
+		return s
+	}
+
+	mantissa, exponent := s[:e], s[e+1:]
+	if !strings.Contains(mantissa, ".") {
+		mantissa += ".0"
+	}
+	if exponent[0] != '+' && exponent[0] != '-' {
+		exponent = "+" + exponent
+	}
+
+	return mantissa + "e" + exponent
+}
+
+// yamlString returns s as a plain YAML scalar if it is read back as the same string, and as a double-quoted one otherwise.
+func yamlString(s string) string {
+	if yamlPlain(s) {
+		return s
+	}
+
+	var b bytes.Buffer
+	encoder := json.NewEncoder(&b)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(s)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// yamlPlain checks if s can be written as a plain scalar. It is conservative, so some strings are quoted although they would not need to be.
+func yamlPlain(s string) bool {
+	if s == "" || s != strings.TrimSpace(s) {
+		return false
+	}
+
+	if strings.ContainsRune("-?:,[]{}#&*!|>'\"%@`.+0123456789", rune(s[0])) {
+		return false
+	}
+
+	if strings.HasSuffix(s, ":") || strings.Contains(s, ": ") || strings.Contains(s, " #") {
+		return false
+	}
+
+	switch strings.ToLower(s) {
+	case "~", "null", "true", "false", "yes", "no", "on", "off", "y", "n", "<<":
+		return false
+	}
+
+	for _, r := range s {
+		if !unicode.IsPrint(r) || r == '\t' {
+			return false
+		}
+	}
+
+	return true
+}
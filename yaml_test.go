@@ -0,0 +1,123 @@
+package dmap
+
+import (
+	"testing"
+)
+
+func TestToYAMLBytes(t *testing.T) {
+	d := MustParseJSONString(`{
+		"name": "example",
+		"port": 8080,
+		"ratio": 0.5,
+		"big": 1e21,
+		"small": 1e-7,
+		"enabled": true,
+		"missing": null,
+		"quoted": ["yes", "1.5", "", "a: b", "line\nbreak", "-x"],
+		"nested": {"items": [{"id": 1, "tags": []}, [1, 2]], "empty": {}}
+	}`)
+
+	want := `big: 1.0e+21
+enabled: true
+missing: null
+name: example
+nested:
+  empty: {}
+  items:
+    - id: 1
+      tags: []
+    - - 1
+      - 2
+port: 8080
+quoted:
+  - "yes"
+  - "1.5"
+  - ""
+  - "a: b"
+  - "line\nbreak"
+  - "-x"
+ratio: 0.5
+small: 1.0e-7
+`
+
+	got, err := d.ToYAMLBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestToYAMLBytesIndent(t *testing.T) {
+	d := MustParseJSONString(`{"a": {"b": [{"c": 1, "d": 2}]}}`)
+
+	want := `a:
+    b:
+        -   c: 1
+            d: 2
+`
+
+	got, err := d.ToYAMLBytesIndent(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+
+	for _, indent := range []int{1, 10} {
+		_, err := d.ToYAMLBytesIndent(indent)
+		if err == nil {
+			t.Errorf("indentation %v: got no error", indent)
+		}
+	}
+}
+
+func TestYAMLNumber(t *testing.T) {
+	tests := map[string]string{
+		"1":         "1",
+		"-1.5":      "-1.5",
+		"1e+21":     "1.0e+21",
+		"1e-07":     "1.0e-07",
+		"1E5":       "1.0e+5",
+		"2.5e10":    "2.5e+10",
+		"-3.25E-10": "-3.25e-10",
+	}
+
+	for in, want := range tests {
+		if got := yamlNumber(in); got != want {
+			t.Errorf("%v: got %v, want %v", in, got, want)
+		}
+	}
+
+	// Numbers parsed with UseNumber are written the same way.
+	d, err := (&Parser{UseNumber: true}).ParseBytes([]byte(`{"v": 1E5}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := d.ToYAMLBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "v: 1.0e+5\n" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestYAMLCodec(t *testing.T) {
+	d := MustParseJSONString(`{"a": [1, "b"]}`)
+
+	got, err := d.Encode(YAMLCodec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "a:\n  - 1\n  - b\n"; string(got) != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+
+	_, err = Parse(YAMLCodec, got)
+	if err == nil {
+		t.Error("decoding: got no error")
+	}
+}